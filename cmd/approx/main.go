@@ -0,0 +1,185 @@
+// Command approx is an interactive calculator for approximate numbers, for
+// exploring how uncertainties propagate through formulas.
+//
+// Each line of input is either an expression, which is evaluated and
+// printed, or an assignment to a variable:
+//
+//     > w = 50±0.5
+//     w = 50±0.5
+//     > l = 100±0.5
+//     l = 100±0.5
+//     > 2*(w+l)
+//     300±2
+//
+// The syntax of the expressions is that of approx.Eval.  The result of the
+// last expression is stored in the variable ans.  Lines starting with a colon
+// are commands, type :help for a list.
+package main
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"os"
+	"sort"
+	"strings"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+const help = `Usage:
+  <expr>                evaluate and print an expression, e.g. 2*(w+l)
+                        or 2*(4.2±0.3)
+  <name> = <expr>       assign an expression to a variable
+  :vars                 list the variables
+  :history              list the lines entered so far
+  :format <format>      print results as: value (4.2±0.3), interval
+                        ([3.9, 4.5]), or relative (4.2±7.14%)
+  :propagation <mode>   propagate uncertainties in the worst case manner
+                        (worst), in quadrature (quadrature), or with
+                        interval arithmetic (interval)
+  :help                 print this help
+  :quit                 exit
+`
+
+func main() {
+	if err := newREPL().run(os.Stdin, os.Stdout); err != nil {
+		fmt.Fprintln(os.Stderr, err)
+		os.Exit(1)
+	}
+}
+
+// repl is the state of an interactive session.
+type repl struct {
+	vars    map[string]approx.Float64
+	history []string
+	format  func(approx.Float64) string
+}
+
+func newREPL() *repl {
+	return &repl{vars: map[string]approx.Float64{}, format: approx.Float64.String}
+}
+
+// run reads lines from in and executes them, writing the results to out,
+// until the end of in or :quit.
+func (r *repl) run(in io.Reader, out io.Writer) error {
+	s := bufio.NewScanner(in)
+	for {
+		fmt.Fprint(out, "> ")
+		if !s.Scan() {
+			fmt.Fprintln(out)
+			return s.Err()
+		}
+		line := strings.TrimSpace(s.Text())
+		if line == "" {
+			continue
+		}
+		if line == ":quit" {
+			return nil
+		}
+		r.history = append(r.history, line)
+		res, err := r.exec(line)
+		if err != nil {
+			res = fmt.Sprintf("error: %v\n", err)
+		}
+		fmt.Fprint(out, res)
+	}
+}
+
+// exec executes a single line of input, and returns its output.
+func (r *repl) exec(line string) (string, error) {
+	if strings.HasPrefix(line, ":") {
+		return r.command(strings.Fields(line[1:]))
+	}
+	name, expr := "ans", line
+	if i := strings.Index(line, "="); i >= 0 {
+		name, expr = strings.TrimSpace(line[:i]), strings.TrimSpace(line[i+1:])
+		if !isName(name) {
+			return "", fmt.Errorf("invalid variable name: %q", name)
+		}
+	}
+	v, err := approx.Eval(expr, r.vars)
+	if err != nil {
+		return "", err
+	}
+	r.vars[name] = v
+	if name == "ans" {
+		return r.format(v) + "\n", nil
+	}
+	return fmt.Sprintf("%v = %v\n", name, r.format(v)), nil
+}
+
+// command executes the command args[0] with the arguments args[1:].
+func (r *repl) command(args []string) (string, error) {
+	if len(args) == 0 {
+		return "", fmt.Errorf("missing command, type :help for a list")
+	}
+	var b strings.Builder
+	switch args[0] {
+	case "help":
+		b.WriteString(help)
+	case "vars":
+		names := make([]string, 0, len(r.vars))
+		for n := range r.vars {
+			names = append(names, n)
+		}
+		sort.Strings(names)
+		for _, n := range names {
+			fmt.Fprintf(&b, "%v = %v\n", n, r.format(r.vars[n]))
+		}
+	case "history":
+		for i, l := range r.history {
+			fmt.Fprintf(&b, "%4d  %v\n", i+1, l)
+		}
+	case "format":
+		if len(args) != 2 {
+			return "", fmt.Errorf("usage: :format value|interval|relative")
+		}
+		f, ok := formats[args[1]]
+		if !ok {
+			return "", fmt.Errorf("unknown format: %q", args[1])
+		}
+		r.format = f
+	case "propagation":
+		if len(args) != 2 {
+			return "", fmt.Errorf("usage: :propagation worst|quadrature|interval")
+		}
+		p, ok := propagators[args[1]]
+		if !ok {
+			return "", fmt.Errorf("unknown propagation: %q", args[1])
+		}
+		approx.SetPropagator(p)
+	default:
+		return "", fmt.Errorf("unknown command: %q, type :help for a list", args[0])
+	}
+	return b.String(), nil
+}
+
+// formats are the formats of :format, by name.
+var formats = map[string]func(approx.Float64) string{
+	"value":    approx.Float64.String,
+	"interval": approx.Float64.IntervalString,
+	"relative": func(f approx.Float64) string {
+		return fmt.Sprintf("%v±%.3g%%", f.Value(), 100*f.RelDelta())
+	},
+}
+
+// propagators are the modes of :propagation, by name.
+var propagators = map[string]approx.Propagator{
+	"worst":      approx.WorstCase{},
+	"quadrature": approx.Quadrature{},
+	"interval":   approx.Interval{},
+}
+
+// isName returns true if s is a valid variable name.
+func isName(s string) bool {
+	if s == "" {
+		return false
+	}
+	for i, c := range s {
+		if !(c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || i > 0 && '0' <= c && c <= '9') {
+			return false
+		}
+	}
+	return true
+}
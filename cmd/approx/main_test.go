@@ -0,0 +1,113 @@
+package main
+
+import (
+	"strings"
+	"testing"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+func TestREPL(t *testing.T) {
+	// Not parallel, since :propagation changes the global propagator.
+	defer approx.SetPropagator(approx.CurrentPropagator())
+	tests := []struct {
+		name     string
+		input    string
+		expected string
+	}{
+		{
+			name:     "expression",
+			input:    "2*(1+2)\n",
+			expected: "> 6±0\n> \n",
+		},
+		{
+			name: "assignment",
+			input: "w = 50±0.5\n" +
+				"l = 100±0.5\n" +
+				"2*(w+l)\n" +
+				"ans - 300\n",
+			expected: "> w = 50±0.5\n" +
+				"> l = 100±0.5\n" +
+				"> 300±2\n" +
+				"> 0±2\n" +
+				"> \n",
+		},
+		{
+			name: "vars and history",
+			input: "x = 1±0.1\n" +
+				"\n" +
+				"y = x*2\n" +
+				":vars\n" +
+				":history\n",
+			expected: "> x = 1±0.1\n" +
+				"> > y = 2±0.2\n" +
+				"> x = 1±0.1\n" +
+				"y = 2±0.2\n" +
+				">    1  x = 1±0.1\n" +
+				"   2  y = x*2\n" +
+				"   3  :vars\n" +
+				"   4  :history\n" +
+				"> \n",
+		},
+		{
+			name: "format",
+			input: "x = 4±0.5\n" +
+				":format interval\n" +
+				"x\n" +
+				":format relative\n" +
+				"x\n" +
+				":format value\n" +
+				"x\n",
+			expected: "> x = 4±0.5\n" +
+				"> > [3.5, 4.5]\n" +
+				"> > 4±12.5%\n" +
+				"> > 4±0.5\n" +
+				"> \n",
+		},
+		{
+			name: "propagation",
+			input: ":propagation quadrature\n" +
+				"(3±0.3) + (4±0.4)\n" +
+				"a = 3±0.3\n" +
+				"a + 4±0.4\n" +
+				":propagation worst\n",
+			expected: "> > 7±0.5\n" +
+				"> a = 3±0.3\n" +
+				"> 7±0.5\n" +
+				"> > \n",
+		},
+		{
+			name: "quit",
+			input: ":quit\n" +
+				"1\n",
+			expected: "> ",
+		},
+		{
+			name: "errors",
+			input: "y\n" +
+				"1x = 2\n" +
+				":\n" +
+				":foo\n" +
+				":format\n" +
+				":format foo\n" +
+				":propagation foo\n",
+			expected: "> error: could not evaluate \"y\": variable \"y\": undefined name\n" +
+				"> error: invalid variable name: \"1x\"\n" +
+				"> error: missing command, type :help for a list\n" +
+				"> error: unknown command: \"foo\", type :help for a list\n" +
+				"> error: usage: :format value|interval|relative\n" +
+				"> error: unknown format: \"foo\"\n" +
+				"> error: unknown propagation: \"foo\"\n" +
+				"> \n",
+		},
+	}
+	for _, test := range tests {
+		var out strings.Builder
+		if err := newREPL().run(strings.NewReader(test.input), &out); err != nil {
+			t.Fatalf("%v: unexpected error: %v", test.name, err)
+		}
+		if actual := out.String(); actual != test.expected {
+			t.Errorf("%v: expected:\n%v\nactual:\n%v", test.name, test.expected, actual)
+		}
+	}
+}
@@ -1,5 +1,5 @@
 module github.com/filmil/approx
 
-go 1.12
+go 1.18
 
 require github.com/google/go-cmp v0.3.1
@@ -66,19 +66,75 @@
 package approx
 
 import (
+	"errors"
 	"fmt"
 	"math"
-	"strconv"
-	"strings"
-	"unicode"
 )
 
+// Float is the constraint for the types in which a Number stores its value
+// and delta.
+type Float interface {
+	~float32 | ~float64
+}
+
+// Number represents a floating point number with a degree of uncertainty,
+// whose value and delta are stored as T.
+//
+// Every Number has an exact value and a delta about it.  The delta may
+// follow a declared Distribution, see WithDistribution.
+//
+// The computations are always done in float64, regardless of T.  The storage
+// type only matters for the memory footprint, e.g. for large arrays of
+// measurements, see Float32.
+type Number[T Float] struct {
+	val, delta T
+	dist       Distribution
+}
+
 // Float64 represents a floating point number with a degree of
-// uncertainty.
+// uncertainty.  This is the approximate number used throughout the package.
+type Float64 = Number[float64]
+
+// Float32 is an approximate number which takes half the memory of a Float64.
+// Converting a Float64 to a Float32 widens the delta to cover the rounding
+// of the value, see NewNumber.
+type Float32 = Number[float32]
+
+// NewNumber creates a new approximate number with value val and delta, stored
+// as T.  The delta is widened to cover the error of rounding val to T, and is
+// rounded up.
 //
-// Every Float64 has an exact value and a delta about it.
-type Float64 struct {
-	val, delta float64
+// Example:
+//     approx.NewNumber[float32](0.1, 0.01) // 0.1±0.010000002
+func NewNumber[T Float](val, delta float64) Number[T] {
+	return Convert[T](New(val, delta))
+}
+
+// Convert converts f to an approximate number stored as T.  See NewNumber.
+func Convert[T Float](f Float64) Number[T] {
+	val := T(f.val)
+	want := f.delta
+	if float64(val) != f.val {
+		want += math.Abs(float64(val) - f.val)
+	}
+	delta := T(want)
+	if float64(delta) < want {
+		delta = nextUp(delta)
+	}
+	return Number[T]{val: val, delta: delta, dist: f.dist}
+}
+
+// nextUp returns the smallest T greater than x.
+func nextUp[T Float](x T) T {
+	if float64(T(1+epsilon)) == 1 {
+		return T(math.Nextafter32(float32(x), float32(math.Inf(1))))
+	}
+	return T(math.Nextafter(float64(x), math.Inf(1)))
+}
+
+// Float64 converts f to a Float64.  The conversion is exact.
+func (f Number[T]) Float64() Float64 {
+	return Float64{val: float64(f.val), delta: float64(f.delta), dist: f.dist}
 }
 
 // String implements Stringer.
@@ -86,73 +142,114 @@ type Float64 struct {
 // This implementation prints the most basic version of the number.  If you want
 // more specific formatting, use Value() and Delta() to extract the components
 // from the number, and format them at will.
-func (f Float64) String() string {
-	return fmt.Sprintf("%v±%v", f.val, f.delta)
+//
+// By default, the value and the delta are printed with full precision.  See
+// SetRounding for printing only their significant digits.
+func (f Number[T]) String() string {
+	if w := f.Float64(); rounding != nil && w.delta > 0 && finite(w) {
+		val, delta := roundPlace(w, rounding.Place(w.delta))
+		return val + "±" + delta
+	}
+	return f.text()
 }
 
 // Value returns the value at the center of f's interval.
-func (f Float64) Value() float64 {
-	return f.val
+func (f Number[T]) Value() float64 {
+	return float64(f.val)
 }
 
 // Delta returns the delta around the interval.  delta is nonnegative.
-func (f Float64) Delta() float64 {
-	return f.delta
+func (f Number[T]) Delta() float64 {
+	return float64(f.delta)
 }
 
 // Min returns the minimal extreme value for f.
-func (f Float64) Min() float64 {
-	return f.val - f.delta
+func (f Number[T]) Min() float64 {
+	return float64(f.val) - float64(f.delta)
 }
 
 // Max returns the maximal extreme value for f.
-func (f Float64) Max() float64 {
-	return f.val + f.delta
+func (f Number[T]) Max() float64 {
+	return float64(f.val) + float64(f.delta)
 }
 
 // RelDelta returns the relative error of f.
-func (f Float64) RelDelta() float64 {
-	return math.Abs(f.delta / f.val)
+func (f Number[T]) RelDelta() float64 {
+	return math.Abs(float64(f.delta) / float64(f.val))
+}
+
+// IsExact returns true if f has no uncertainty, that is if its delta is zero.
+func (f Number[T]) IsExact() bool {
+	return f.delta == 0
+}
+
+// IsFinite returns true if neither the value nor the delta of f is NaN or
+// infinite.
+func (f Number[T]) IsFinite() bool {
+	return !f.IsNaN() && !math.IsInf(float64(f.val), 0) && !math.IsInf(float64(f.delta), 0)
+}
+
+// IsNaN returns true if the value or the delta of f is NaN.
+func (f Number[T]) IsNaN() bool {
+	return f.val != f.val || f.delta != f.delta
+}
+
+// IsZeroWidth returns true if the interval of f is a single point, that is if
+// Min and Max are equal.  Unlike IsExact, this is also true for a delta
+// which is too small to change the value, e.g. 1e20±1.
+func (f Number[T]) IsZeroWidth() bool {
+	return f.Min() == f.Max()
+}
+
+// ErrInvalid is returned for approximate numbers which are not valid, see
+// Validate.
+var ErrInvalid = errors.New("invalid approximate number")
+
+// Validate returns an error wrapping ErrInvalid if the value or the delta of
+// f is NaN or infinite, or if the delta is negative.  This allows pipelines to
+// reject malformed inputs early, rather than propagate NaN silently.
+func (f Number[T]) Validate() error {
+	switch {
+	case f.val != f.val:
+		return fmt.Errorf("%v: value is NaN: %w", f, ErrInvalid)
+	case f.delta != f.delta:
+		return fmt.Errorf("%v: delta is NaN: %w", f, ErrInvalid)
+	case math.IsInf(float64(f.val), 0):
+		return fmt.Errorf("%v: value is infinite: %w", f, ErrInvalid)
+	case math.IsInf(float64(f.delta), 0):
+		return fmt.Errorf("%v: delta is infinite: %w", f, ErrInvalid)
+	case f.delta < 0:
+		return fmt.Errorf("%v: delta is negative: %w", f, ErrInvalid)
+	}
+	return nil
 }
 
 // Parse parses an uncertain number from a string.
 //
+// Besides the form value±delta, where the ± may also be written in ASCII as
+// +- or +/-, and the delta may be given relative to the value in percent,
+// the following forms are accepted:
+//   - the concise notation used e.g. by CODATA, in which the delta is given
+//     in parentheses as the uncertainty in the last digits of the value.  An
+//     exponent applies to both.
+//   - a value prefixed with ~, whose implied uncertainty is half a unit in
+//     its last digit.
+//   - an interval [min, max], see NewMinMax.
+//   - the scientific notation with an exponent shared by the value and the
+//     delta, such as (1.23±0.04)e5 or (1.23±0.04)×10^5.
+//
 // Example:
 //     approx.Parse("4.2±0.3") -> {4.2, 0.3}
+//     approx.Parse("4.2 +/- 0.3") -> {4.2, 0.3}
+//     approx.Parse("50 ± 1%") -> {50, 0.5}
+//     approx.Parse("1.234(5)e-3") -> {0.001234, 0.000005}
+//     approx.Parse("~50") -> {50, 0.5}
+//     approx.Parse("[49.5, 50.5]") -> {50, 0.5}
+//     approx.Parse("(1.23±0.04)e5") -> {123000, 4000}
+//
+// The returned error is a *ParseError.
 func Parse(s string) (Float64, error) {
-	// First strip all spaces from the thing.
-	s = strings.Map(func(r rune) rune {
-		if unicode.IsSpace(r) {
-			return -1
-		}
-		return r
-	}, s)
-	splitstr := strings.Split(s, "±")
-	if len(splitstr) == 1 {
-		// Exact value
-	}
-	switch len(splitstr) {
-	case 1: // Exact
-		val, err := strconv.ParseFloat(splitstr[0], 64)
-		if err != nil {
-			return Float64{}, fmt.Errorf("could not parse as exact float: %v", splitstr)
-		}
-		return Float64{val: val, delta: 0.0}, nil
-	case 2: // Inexact
-		val, err := strconv.ParseFloat(splitstr[0], 64)
-		if err != nil {
-			return Float64{}, fmt.Errorf("could not parse as exact float: %v", splitstr)
-		}
-		delta, err := strconv.ParseFloat(splitstr[1], 64)
-		if err != nil {
-			return Float64{}, fmt.Errorf("could not parse as delta float: %v", splitstr)
-		}
-		return Float64{val: val, delta: math.Abs(delta)}, nil
-
-	default: // Everything else
-		return Float64{}, fmt.Errorf("could not parse as approximate number: %v", splitstr)
-	}
-	return Float64{}, nil
+	return parseLiteral(s, literal.float64)
 }
 
 // New constructs a new Float64 from exact float components.
@@ -175,114 +272,322 @@ func NewMinMax(min, max float64) (Float64, error) {
 }
 
 // Add computes a sum of two approximate numbers a and b.
+//
+// The uncertainty is propagated by the current Propagator, see SetPropagator.
 func Add(a, b Float64) Float64 {
-	return New(a.val+b.val, a.delta+b.delta)
+	return propagator.Add(a, b)
 }
 
 // Sub computes a diference when subtracting a from b.
+//
+// The uncertainty is propagated by the current Propagator, see SetPropagator.
 func Sub(a, b Float64) Float64 {
-	return New(a.val-b.val, a.delta+b.delta)
+	return propagator.Sub(a, b)
 }
 
 // Mul computes a multplication of a and b.
+//
+// The uncertainty is propagated by the current Propagator, see SetPropagator.
 func Mul(a, b Float64) Float64 {
-	relA := math.Abs(a.delta / a.val)
-	relB := math.Abs(b.delta / b.val)
-	rel := relA + relB
-	val := a.val * b.val
-	delta := math.Abs(val * rel)
-	return New(val, delta)
+	return propagator.Mul(a, b)
 }
 
 // Mul computes a scalar product of f with a number c.
-func (f Float64) Mul(c float64) Float64 {
-	return New(c*f.val, math.Abs(c*f.delta))
+func (f Number[T]) Mul(c float64) Number[T] {
+	w := f.Float64()
+	return Convert[T](New(c*w.val, math.Abs(c*w.delta)).WithDistribution(f.dist))
+}
+
+// AddC computes f+c for an exact number c.
+func (f Number[T]) AddC(c float64) Number[T] {
+	w := f.Float64()
+	w.val += c
+	return Convert[T](w)
+}
+
+// SubC computes f-c for an exact number c.
+func (f Number[T]) SubC(c float64) Number[T] {
+	w := f.Float64()
+	w.val -= c
+	return Convert[T](w)
+}
+
+// DivC computes f/c for an exact number c.
+func (f Number[T]) DivC(c float64) Number[T] {
+	w := f.Float64()
+	return Convert[T](New(w.val/c, math.Abs(w.delta/c)).WithDistribution(f.dist))
+}
+
+// RDivC computes c/f for an exact number c, same as Div(New(c, 0), f).
+func (f Number[T]) RDivC(c float64) Number[T] {
+	return Convert[T](propagator.Div(New(c, 0), f.Float64()))
+}
+
+// Add computes f+g, same as Add(f, g).  The arithmetic methods allow
+// formulas to be written as chains:
+//
+//     perimeter := width.Add(length).Mul(2)
+func (f Number[T]) Add(g Number[T]) Number[T] {
+	return Convert[T](propagator.Add(f.Float64(), g.Float64()))
+}
+
+// Sub computes f-g, same as Sub(f, g).
+func (f Number[T]) Sub(g Number[T]) Number[T] {
+	return Convert[T](propagator.Sub(f.Float64(), g.Float64()))
+}
+
+// MulA computes f*g, same as Mul(f, g).  See Mul for multiplication with an
+// exact number.
+func (f Number[T]) MulA(g Number[T]) Number[T] {
+	return Convert[T](propagator.Mul(f.Float64(), g.Float64()))
+}
+
+// DivA computes f/g, same as Div(f, g).
+func (f Number[T]) DivA(g Number[T]) Number[T] {
+	return Convert[T](propagator.Div(f.Float64(), g.Float64()))
+}
+
+// FMA computes a*b+c, with the value computed by math.FMA, that is with only
+// one rounding.  This reduces the accumulated rounding error in long
+// dot-product style loops:
+//
+//     sum := approx.New(0, 0)
+//     for i := range xs {
+//         sum = approx.FMA(xs[i], ys[i], sum)
+//     }
+//
+// The uncertainty is propagated by the current Propagator in a single step,
+// see SetPropagator.  To the first order, it is the same as that of
+// Add(Mul(a, b), c).  A Propagator which has no FMA method of its own, such as
+// one defined outside of this package, computes Add(Mul(a, b), c).
+func FMA(a, b, c Float64) Float64 {
+	return fma(propagator, a, b, c)
+}
+
+// fma computes a*b+c with the Propagator p, in a single step if p supports
+// it.
+func fma(p Propagator, a, b, c Float64) Float64 {
+	if f, ok := p.(fmaPropagator); ok {
+		return f.FMA(a, b, c)
+	}
+	return p.Add(p.Mul(a, b), c)
 }
 
 // Div computes a quotient of a and b. Zeroes cause infinities, as expected.
+//
+// The uncertainty is propagated by the current Propagator, see SetPropagator.
 func Div(a, b Float64) Float64 {
-	relA := math.Abs(a.delta / a.val)
-	relB := math.Abs(b.delta / b.val)
-	rel := relA + relB
-	val := a.val / b.val
-	delta := math.Abs(val * rel)
-	return New(val, delta)
+	return propagator.Div(a, b)
+}
+
+// ErrDivisorContainsZero is returned when dividing by an approximate number
+// whose interval contains zero.  The quotient is then unbounded.
+var ErrDivisorContainsZero = errors.New("divisor interval contains zero")
+
+// DivChecked computes a quotient of a and b like Div does, but returns an
+// error wrapping ErrDivisorContainsZero if the interval of b contains zero.
+func DivChecked(a, b Float64) (Float64, error) {
+	if b.Min() <= 0 && 0 <= b.Max() {
+		return Float64{}, fmt.Errorf("could not divide %v by %v: %w", a, b, ErrDivisorContainsZero)
+	}
+	return Div(a, b), nil
+}
+
+// ErrCancellation is returned when subtracting two nearly equal approximate
+// numbers leaves a difference with a large relative error.
+var ErrCancellation = errors.New("catastrophic cancellation")
+
+// SubChecked computes a difference of a and b like Sub does, but flags a
+// catastrophic cancellation: if the relative error of the difference exceeds
+// maxRelDelta, the difference is returned together with an error wrapping
+// ErrCancellation.  A difference of zero with a nonzero delta is always
+// flagged.
+//
+// Example:
+//     d, err := approx.SubChecked(approx.New(10.5, 0.25), approx.New(10, 0.25), 0.5)
+//     // d is 0.5±0.5, errors.Is(err, approx.ErrCancellation) is true
+func SubChecked(a, b Float64, maxRelDelta float64) (Float64, error) {
+	r := Sub(a, b)
+	if r.delta != 0 && !(r.RelDelta() <= maxRelDelta) {
+		return r, fmt.Errorf("subtracting %v from %v gives %v, relative error exceeds %v: %w", b, a, r, maxRelDelta, ErrCancellation)
+	}
+	return r, nil
 }
 
 // Lt returns true if f is definitely less than t.
-func (f Float64) Lt(t Float64) bool {
-	return f.val+f.delta < t.val-t.delta
+//
+// The comparisons respect the distributions of the numbers.  A bounded or
+// unspecified distribution spans [Min(), Max()], while a Gaussian spans the
+// coverage interval of a few standard deviations around its value, see
+// SetCoverage.
+func (f Number[T]) Lt(t Number[T]) bool {
+	return f.high() < t.low()
 }
 
 // Le returns true if f is definitely either less than, or equal to t.
-func (f Float64) Le(t Float64) bool {
-	return f.val+f.delta <= t.val-t.delta
+func (f Number[T]) Le(t Number[T]) bool {
+	return f.high() <= t.low()
 }
 
 // Gt returns true if f is definitely greater than t.
-func (f Float64) Gt(t Float64) bool {
+func (f Number[T]) Gt(t Number[T]) bool {
 	return t.Le(f)
 }
 
 // Ge returns true if f is definitely either greather than, or equal to t.
-func (f Float64) Ge(t Float64) bool {
+func (f Number[T]) Ge(t Number[T]) bool {
 	return t.Lt(f)
 }
 
-// Overlap returns true if t and f may overlap.
+// Overlap returns true if t and f may overlap.  Like Lt, it respects the
+// distributions of f and t.
 func Overlap(f, t Float64) bool {
 	return !f.Le(t) && !t.Le(f)
 }
 
-// eqFunc is a dirty trick which compares function based on their address in
-// memory.
-func eqFunc(f1, f2 func(float64) float64) bool {
-	return fmt.Sprintf("%p", f1) == fmt.Sprintf("%p", f2)
+// Apply applies the function fx to f.
+//
+// Based on first order Taylor expansion of fx around f:
+// f := x + dx
+// fx(f) = x + fx'(f) * dx.
+//
+// For functions with a registered derivative, the computation is exact, see
+// RegisterDerivative.  For other functions, the computation is via computing
+// numeric derivative around the centerpoint of f, for which 'eps' is the
+// interval to compute numeric derivative on.  If eps is zero or negative, the
+// interval is chosen automatically, as in ApplyAuto.
+//
+// Deprecated: a good eps is hard to guess, as too large an eps gives a
+// derivative which is off due to the curvature of fx, and too small an eps
+// gives a derivative which is off due to rounding.  Use ApplyAuto instead.
+func (f Number[T]) Apply(fx func(float64) float64, eps float64) Number[T] {
+	w := f.Float64()
+	dfx := derivative(fx, w.val, eps)
+	return Convert[T](New(fx(w.val), math.Abs(dfx*w.delta)).WithDistribution(f.dist))
 }
 
-// applyLog computes approximate value for a natural logarithm.
-//
-// Based on first-order Taylor expansion around x:
-//   ln(x+dx) = ln(x) + 1/x * dx
-func (f Float64) applyLog() Float64 {
-	delta := f.delta / f.val
-	val := math.Log(f.val)
-	return New(val, delta)
+// ApplyAuto applies the function fx to f, same as Apply, but chooses the
+// interval for the numeric derivative automatically.  The interval scales
+// with the magnitude of f's value, such that the truncation error of the
+// numeric derivative balances the float64 rounding error.
+func (f Number[T]) ApplyAuto(fx func(float64) float64) Number[T] {
+	return f.Apply(fx, 0)
 }
 
-// applyExp computes approximate value for e^x.
+// ErrSingular is returned when applying a function to an approximate number
+// whose interval contains a pole of the function, or reaches out of the
+// domain of the function.
+var ErrSingular = errors.New("function is singular within the interval")
+
+// singularityProbes is the number of subintervals in which ApplyChecked
+// probes the function.
+const singularityProbes = 8
+
+// ApplyChecked applies the function fx to f, same as Apply, but first checks
+// that fx is well behaved over the interval of f.  An error wrapping
+// ErrSingular is returned if fx is not finite at the endpoints, at the center
+// or at a number of points in between, or if fx appears to have a pole
+// between the probed points.  For example, applying math.Log to 1±2 or
+// 1/x to 1±2 fails.
 //
-// Based on first-order Taylor expansion around x:
-//   e^(x+dx) = e^x + e^x*dx
-func (f Float64) applyExp() Float64 {
-	val := math.Exp(f.val)
-	delta := val * f.delta
-	return New(val, delta)
+// The check is a heuristic, which can be fooled by functions which vary
+// wildly within the interval.
+func (f Number[T]) ApplyChecked(fx func(float64) float64, eps float64) (Number[T], error) {
+	min, max := f.Min(), f.Max()
+	x := make([]float64, singularityProbes+1)
+	y := make([]float64, singularityProbes+1)
+	for i := range x {
+		x[i] = min + (max-min)*float64(i)/singularityProbes
+		y[i] = fx(x[i])
+	}
+	x = append(x, f.Value())
+	y = append(y, fx(f.Value()))
+	for i := range x {
+		if math.IsNaN(y[i]) || math.IsInf(y[i], 0) {
+			return Number[T]{}, fmt.Errorf("could not apply function to %v: f(%v)=%v: %w", f, x[i], y[i], ErrSingular)
+		}
+	}
+	for i := 0; i < singularityProbes; i++ {
+		if y[i]*y[i+1] < 0 && hasPole(fx, x[i], x[i+1], y[i], y[i+1]) {
+			return Number[T]{}, fmt.Errorf("could not apply function to %v: pole between %v and %v: %w",
+				f, x[i], x[i+1], ErrSingular)
+		}
+	}
+	return f.Apply(fx, eps), nil
 }
 
-// Apply applies the function fx to f.
+// hasPole returns true if fx, which changes sign between a and b, does so
+// through a pole rather than through a zero.  The interval is bisected
+// towards the sign change: near a zero fx gets smaller, near a pole it gets
+// larger.
+func hasPole(fx func(float64) float64, a, b, fa, fb float64) bool {
+	bound := math.Max(math.Abs(fa), math.Abs(fb))
+	for i := 0; i < 64; i++ {
+		m := a + (b-a)/2
+		if m == a || m == b {
+			break
+		}
+		fm := fx(m)
+		switch {
+		case math.IsNaN(fm) || math.IsInf(fm, 0):
+			return true
+		case fm == 0:
+			return false
+		case fa*fm < 0:
+			b, fb = m, fm
+		default:
+			a, fa = m, fm
+		}
+	}
+	return math.Min(math.Abs(fa), math.Abs(fb)) > bound
+}
+
+// ApplyD applies the function fx, whose derivative is dfx, to f.
 //
-// Based on first order Taylor expansion of fx around f:
-// f := x + dx
-// fx(f) = x + fx'(f) * dx.
+// Based on first order Taylor expansion of fx around f, same as Apply.  Since
+// the derivative is given, the computation is exact, and there is no need to
+// choose an interval for a numeric derivative.
+func (f Number[T]) ApplyD(fx, dfx func(float64) float64) Number[T] {
+	w := f.Float64()
+	return Convert[T](New(fx(w.val), math.Abs(dfx(w.val)*w.delta)).WithDistribution(f.dist))
+}
+
+// ApplyN applies the function f of several arguments to args.
 //
-// For well known functions, the computation is exact.  For user-defined
-// functions, the computation is via computing numeric derivative around the
-// centerpoint of f, for which 'eps' is the interval to compute numeric
-// derivative on.
-func (f Float64) Apply(fx func(float64) float64, eps float64) Float64 {
-	// Special-case some interesting functions.
-	if eqFunc(fx, math.Log) {
-		return f.applyLog()
+// Based on first order Taylor expansion of f around args, with the partial
+// derivatives computed numerically:
+//   d(f) = sum(|df/dx_i| * dx_i)
+// The arguments are treated as independent, and their contributions are
+// added up in the worst case manner.  See Multivariate.Propagate for
+// correlated arguments, or for adding up the contributions in quadrature.
+func ApplyN(f func(...float64) float64, args ...Float64) Float64 {
+	return ApplyNGrad(f, func(x ...float64) []float64 {
+		g := make([]float64, len(x))
+		for i, xi := range x {
+			h := step(xi)
+			x[i] = xi + h
+			fmax := f(x...)
+			x[i] = xi - h
+			fmin := f(x...)
+			x[i] = xi
+			g[i] = (fmax - fmin) / (2 * h)
+		}
+		return g
+	}, args...)
+}
+
+// ApplyNGrad applies the function f of several arguments to args, same as
+// ApplyN, but with the gradient of f supplied by grad instead of computed
+// numerically.
+func ApplyNGrad(f func(...float64) float64, grad func(...float64) []float64, args ...Float64) Float64 {
+	x := make([]float64, len(args))
+	for i, a := range args {
+		x[i] = a.val
 	}
-	if eqFunc(fx, math.Exp) {
-		return f.applyExp()
+	g := grad(x...)
+	r := New(f(x...), 0)
+	for i, a := range args {
+		r = New(r.val, r.delta+math.Abs(g[i]*a.delta)).WithDistribution(combineDist(r, a))
 	}
-
-	// Central difference numeric derivative computation.
-	fmin := fx(f.val - eps)
-	fmax := fx(f.val + eps)
-	d := 2 * eps
-	dfx := (fmax - fmin) / d
-	return New(fx(f.val), math.Abs(dfx*f.delta))
+	return r
 }
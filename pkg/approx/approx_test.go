@@ -1,6 +1,7 @@
 package approx
 
 import (
+	"errors"
 	"fmt"
 	"math"
 	"testing"
@@ -21,28 +22,28 @@ func TestParse(t *testing.T) {
 	}{
 		{
 			input:    "0",
-			expected: Float64{0, 0},
+			expected: Float64{val: 0, delta: 0},
 		},
 		{
 			input:    "1",
-			expected: Float64{1, 0},
+			expected: Float64{val: 1, delta: 0},
 		},
 		{
 			// 4.2±0.3
 			input:    "-1.23",
-			expected: Float64{-1.23, 0},
+			expected: Float64{val: -1.23, delta: 0},
 		},
 		{
 			input:    "4.2±0.3",
-			expected: Float64{4.2, 0.3},
+			expected: Float64{val: 4.2, delta: 0.3},
 		},
 		{
 			input:    "4.2±-0.3",
-			expected: Float64{4.2, 0.3},
+			expected: Float64{val: 4.2, delta: 0.3},
 		},
 		{
 			input: "4.2±--0.3",
-			err:   fmt.Errorf("could not parse as delta float: [4.2 --0.3]"),
+			err:   fmt.Errorf(`could not parse "4.2±--0.3": invalid delta "--0.3" at offset 5`),
 		},
 	}
 	for _, test := range tests {
@@ -72,12 +73,12 @@ func TestNew(t *testing.T) {
 		expected Float64
 	}{
 		{
-			expected: Float64{0, 0},
+			expected: Float64{val: 0, delta: 0},
 		},
 		{
 			v:        10.0,
 			d:        -1.0,
-			expected: Float64{10, 1},
+			expected: Float64{val: 10, delta: 1},
 		},
 	}
 	for _, test := range tests {
@@ -98,17 +99,17 @@ func TestNewMinMax(t *testing.T) {
 		expected Float64
 	}{
 		{
-			expected: Float64{0, 0},
+			expected: Float64{val: 0, delta: 0},
 		},
 		{
 			min:      0,
 			max:      10,
-			expected: Float64{5, 5},
+			expected: Float64{val: 5, delta: 5},
 		},
 		{
 			min:      1,
 			max:      10,
-			expected: Float64{5.5, 4.5},
+			expected: Float64{val: 5.5, delta: 4.5},
 		},
 	}
 	for _, test := range tests {
@@ -258,7 +259,7 @@ func TestApply(t *testing.T) {
 			f: func(x float64) float64 {
 				return x * x
 			},
-			expected: must(Parse("100±1.9999999999988916")),
+			expected: must(Parse("100±2.0000000000036287")),
 		},
 	}
 	for _, test := range tests {
@@ -271,3 +272,433 @@ func TestApply(t *testing.T) {
 		})
 	}
 }
+
+func TestDivChecked(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		op1, op2 Float64
+		expected Float64
+		err      error
+	}{
+		{
+			op1:      New(1, 0),
+			op2:      New(2, 1),
+			expected: New(0.5, 0.25),
+		},
+		{
+			op1: New(1, 0),
+			op2: New(1, 1),
+			err: ErrDivisorContainsZero,
+		},
+		{
+			op1: New(1, 0),
+			op2: New(-1, 2),
+			err: ErrDivisorContainsZero,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("(%v;%v)", test.op1, test.op2), func(t *testing.T) {
+			actual, err := DivChecked(test.op1, test.op2)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSubChecked(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		op1, op2 Float64
+		expected Float64
+		err      error
+	}{
+		{
+			op1:      New(10, 0.1),
+			op2:      New(4, 0.2),
+			expected: New(6, 0.30000000000000004),
+		},
+		{
+			op1:      New(10, 0.25),
+			op2:      New(9, 0.25),
+			expected: New(1, 0.5),
+		},
+		{
+			op1:      New(10, 0.5),
+			op2:      New(9, 0.5),
+			expected: New(1, 1),
+			err:      ErrCancellation,
+		},
+		{
+			op1:      New(10, 0.01),
+			op2:      New(10, 0.01),
+			expected: New(0, 0.02),
+			err:      ErrCancellation,
+		},
+		{
+			op1:      New(10, 0),
+			op2:      New(10, 0),
+			expected: New(0, 0),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("(%v;%v)", test.op1, test.op2), func(t *testing.T) {
+			actual, err := SubChecked(test.op1, test.op2, 0.5)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNumber(t *testing.T) {
+	t.Parallel()
+	a := NewNumber[float32](0.1, 0.01)
+	tests := []struct {
+		name     string
+		actual   string
+		expected string
+	}{
+		{name: "float32", actual: a.String(), expected: "0.1±0.010000002"},
+		{name: "covers rounding", actual: fmt.Sprint(a.Min() <= 0.09 && a.Max() >= 0.11), expected: "true"},
+		{name: "arithmetic", actual: a.Add(a).Mul(2).String(), expected: "0.4±0.040000007"},
+		{name: "exact", actual: NewNumber[float32](1, 0.5).String(), expected: "1±0.5"},
+		{name: "float64", actual: Convert[float64](New(0.1, 0.01)).String(), expected: "0.1±0.01"},
+		{name: "overflow", actual: NewNumber[float32](1e40, 1).String(), expected: "+Inf±+Inf"},
+		{
+			name:     "distribution",
+			actual:   Convert[float32](New(1, 0.5).WithDistribution(Uniform)).Distribution().String(),
+			expected: "uniform",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if test.actual != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
+
+func TestMethods(t *testing.T) {
+	t.Parallel()
+	width, length := New(50, 0.5), New(100, 0.5)
+	tests := []struct {
+		name     string
+		actual   Float64
+		expected Float64
+	}{
+		{name: "perimeter", actual: width.Add(length).Mul(2), expected: New(300, 2)},
+		{name: "AddC", actual: width.AddC(2), expected: New(52, 0.5)},
+		{name: "SubC", actual: width.SubC(2), expected: New(48, 0.5)},
+		{name: "DivC", actual: width.DivC(-2), expected: New(-25, 0.25)},
+		{name: "RDivC", actual: width.RDivC(100), expected: New(2, 0.02)},
+		{name: "dist", actual: width.WithDistribution(Uniform).AddC(1), expected: New(51, 0.5).WithDistribution(Uniform)},
+		{name: "Add", actual: width.Add(length), expected: Add(width, length)},
+		{name: "Sub", actual: length.Sub(width), expected: Sub(length, width)},
+		{name: "MulA", actual: width.MulA(length), expected: Mul(width, length)},
+		{name: "DivA", actual: length.DivA(width), expected: Div(length, width)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if !cmp.Equal(test.actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
+
+func TestFMA(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b, c  Float64
+		expected Float64
+	}{
+		{a: New(2, 0.1), b: New(3, 0.2), c: New(1, 0.5), expected: New(7, 1.2)},
+		{a: New(0, 0.1), b: New(3, 0.2), c: New(1, 0), expected: New(1, 0.30000000000000004)},
+		{
+			// The exact result, not representable as a product plus a sum of
+			// float64s.
+			a:        New(1.0000000009313226, 0),
+			b:        New(0.9999999990686774, 0),
+			c:        New(-1, 0),
+			expected: New(-8.673617379884035e-19, 0),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("(%v;%v;%v)", test.a, test.b, test.c), func(t *testing.T) {
+			actual := FMA(test.a, test.b, test.c)
+			if !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+// Not parallel: changes the package-wide propagator.
+func TestFMAPropagators(t *testing.T) {
+	tests := []struct {
+		name     string
+		p        Propagator
+		a, b, c  Float64
+		expected Float64
+	}{
+		{name: "interval", p: Interval{}, a: New(1, 1), b: New(1, 1), c: New(0, 0), expected: New(2, 2)},
+		{name: "interval negative", p: Interval{}, a: New(-1, 1), b: New(3, 1), c: New(1, 0.5), expected: New(-3, 4.5)},
+		{name: "quadrature", p: Quadrature{}, a: New(2, 0.1), b: New(3, 0.2), c: New(1, 0.5), expected: New(7, math.Sqrt(0.3*0.3+0.4*0.4+0.5*0.5))},
+		{name: "guard", p: &Guard{Propagator: Interval{}}, a: New(1, 1), b: New(1, 1), c: New(0, 0), expected: New(2, 2)},
+		{name: "custom", p: struct{ Propagator }{Interval{}}, a: New(1, 1), b: New(1, 1), c: New(0, 0), expected: New(2, 2)},
+	}
+	for _, test := range tests {
+		prev := SetPropagator(test.p)
+		actual := FMA(test.a, test.b, test.c)
+		SetPropagator(prev)
+		if !near(actual, test.expected) {
+			t.Errorf("%v: FMA(%v, %v, %v): expected: %v, actual: %v", test.name, test.a, test.b, test.c, test.expected, actual)
+		}
+		// The result must enclose all a*b+c over the intervals.
+		if _, ok := test.p.(Quadrature); ok {
+			continue
+		}
+		for _, x := range []float64{test.a.Min(), test.a.Max()} {
+			for _, y := range []float64{test.b.Min(), test.b.Max()} {
+				for _, z := range []float64{test.c.Min(), test.c.Max()} {
+					if r := x*y + z; r < actual.Min() || r > actual.Max() {
+						t.Errorf("%v: %v*%v+%v = %v is outside of %v", test.name, x, y, z, r, actual.IntervalString())
+					}
+				}
+			}
+		}
+	}
+}
+
+func TestApplyD(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    Float64
+		f, df    func(float64) float64
+		expected Float64
+	}{
+		{
+			name:     "x^2",
+			input:    New(10, 0.1),
+			f:        func(x float64) float64 { return x * x },
+			df:       func(x float64) float64 { return 2 * x },
+			expected: New(100, 2),
+		},
+		{
+			name:     "-x",
+			input:    New(10, 0.1),
+			f:        func(x float64) float64 { return -x },
+			df:       func(x float64) float64 { return -1 },
+			expected: New(-10, 0.1),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.input.ApplyD(test.f, test.df)
+			if !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("was : %v\nwant: %v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestApplyN(t *testing.T) {
+	t.Parallel()
+	volume := func(x ...float64) float64 { return x[0] * x[1] * x[2] }
+	tests := []struct {
+		name     string
+		args     []Float64
+		expected Float64
+	}{
+		{
+			name:     "no uncertainty",
+			args:     []Float64{New(1, 0), New(2, 0), New(3, 0)},
+			expected: New(6, 0),
+		},
+		{
+			name:     "all uncertain",
+			args:     []Float64{New(1, 0.1), New(2, 0.1), New(3, 0.1)},
+			expected: New(6, 1.1),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual := ApplyN(volume, test.args...)
+			if actual.Value() != test.expected.Value() ||
+				math.Abs(actual.Delta()-test.expected.Delta()) > 1e-9 {
+				t.Errorf("was : %v\nwant: %v", actual, test.expected)
+			}
+			grad := func(x ...float64) []float64 {
+				return []float64{x[1] * x[2], x[0] * x[2], x[0] * x[1]}
+			}
+			actual = ApplyNGrad(volume, grad, test.args...)
+			if actual.Value() != test.expected.Value() ||
+				math.Abs(actual.Delta()-test.expected.Delta()) > 1e-12 {
+				t.Errorf("grad: was : %v\nwant: %v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestApplyAuto(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    Float64
+		f        func(float64) float64
+		expected Float64
+	}{
+		{
+			name:     "x^2",
+			input:    New(10, 0.1),
+			f:        func(x float64) float64 { return x * x },
+			expected: New(100, 2),
+		},
+		{
+			name:     "large x^2",
+			input:    New(1e10, 1e8),
+			f:        func(x float64) float64 { return x * x },
+			expected: New(1e20, 2e18),
+		},
+		{
+			name:     "small x^2",
+			input:    New(1e-10, 1e-12),
+			f:        func(x float64) float64 { return x * x },
+			expected: New(1e-20, 2e-22),
+		},
+		{
+			name:     "1/x",
+			input:    New(1e-3, 1e-5),
+			f:        func(x float64) float64 { return 1 / x },
+			expected: New(1e3, 10),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.input.ApplyAuto(test.f)
+			if math.Abs(actual.Value()/test.expected.Value()-1) > 1e-15 ||
+				math.Abs(actual.Delta()/test.expected.Delta()-1) > 1e-8 {
+				t.Errorf("was : %v\nwant: %v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestApplyChecked(t *testing.T) {
+	t.Parallel()
+	inv := func(x float64) float64 { return 1 / x }
+	tests := []struct {
+		name     string
+		input    Float64
+		f        func(float64) float64
+		expected Float64
+		err      error
+	}{
+		{
+			name:     "log ok",
+			input:    New(1, 0.1),
+			f:        math.Log,
+			expected: New(0, 0.1),
+		},
+		{
+			name:  "log of negative",
+			input: New(1, 2),
+			f:     math.Log,
+			err:   ErrSingular,
+		},
+		{
+			name:  "1/x at zero",
+			input: New(0, 1),
+			f:     inv,
+			err:   ErrSingular,
+		},
+		{
+			name:  "1/x across zero",
+			input: New(1, 2),
+			f:     inv,
+			err:   ErrSingular,
+		},
+		{
+			name:  "tan across pole",
+			input: New(1.5, 0.1),
+			f:     math.Tan,
+			err:   ErrSingular,
+		},
+		{
+			name:     "sin across zero",
+			input:    New(0.1, 1),
+			f:        math.Sin,
+			expected: New(0.1, 1).Apply(math.Sin, 0),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.input.ApplyChecked(test.f, 0)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("was : %v\nwant: %v", actual, test.expected)
+			}
+		})
+	}
+}
+
+func TestPredicates(t *testing.T) {
+	t.Parallel()
+	nan, inf := math.NaN(), math.Inf(1)
+	tests := []struct {
+		f                                   Float64
+		exact, finite, isNaN, zeroWidth, ok bool
+	}{
+		{f: New(4.2, 0.3), finite: true, ok: true},
+		{f: New(4.2, 0), exact: true, finite: true, zeroWidth: true, ok: true},
+		{f: New(1e20, 1), finite: true, zeroWidth: true, ok: true},
+		{f: NewNumber[float32](1e20, 1).Float64(), finite: true, ok: true},
+		{f: New(nan, 0), exact: true, isNaN: true},
+		{f: New(1, nan), isNaN: true},
+		{f: New(-inf, 0), exact: true, zeroWidth: true},
+		{f: New(1, inf)},
+		{f: Float64{val: 1, delta: -1}, finite: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprint(test.f), func(t *testing.T) {
+			t.Parallel()
+			if actual := test.f.IsExact(); actual != test.exact {
+				t.Errorf("IsExact: expected: %v, actual: %v", test.exact, actual)
+			}
+			if actual := test.f.IsFinite(); actual != test.finite {
+				t.Errorf("IsFinite: expected: %v, actual: %v", test.finite, actual)
+			}
+			if actual := test.f.IsNaN(); actual != test.isNaN {
+				t.Errorf("IsNaN: expected: %v, actual: %v", test.isNaN, actual)
+			}
+			if actual := test.f.IsZeroWidth(); actual != test.zeroWidth {
+				t.Errorf("IsZeroWidth: expected: %v, actual: %v", test.zeroWidth, actual)
+			}
+			err := test.f.Validate()
+			if (err == nil) != test.ok || err != nil && !errors.Is(err, ErrInvalid) {
+				t.Errorf("Validate: expected ok: %v, actual: %v", test.ok, err)
+			}
+		})
+	}
+}
@@ -0,0 +1,55 @@
+// Package approxcmp provides options for comparing approximate numbers with
+// github.com/google/go-cmp, so that tests can compare values which contain
+// approximate numbers without reaching into their unexported fields.
+//
+// Example:
+//
+//     if diff := cmp.Diff(want, got, approxcmp.Comparer()); diff != "" {
+//         t.Errorf("unexpected result (-want +got):\n%v", diff)
+//     }
+package approxcmp
+
+import (
+	"math"
+
+	"github.com/filmil/approx/pkg/approx"
+	"github.com/google/go-cmp/cmp"
+)
+
+// Comparer returns an option which treats two approximate numbers as equal if
+// their intervals overlap, including when they only touch.  It is the same
+// as Within(1).
+func Comparer() cmp.Option {
+	return Within(1)
+}
+
+// Within returns an option which treats two approximate numbers a and b as
+// equal if their values are apart by at most n times the sum of their deltas:
+//   |a.Value()-b.Value()| <= n*(a.Delta()+b.Delta())
+// The option applies to Float64 and Float32.
+func Within(n float64) cmp.Option {
+	within := func(a, b approx.Float64) bool {
+		return math.Abs(a.Value()-b.Value()) <= n*(a.Delta()+b.Delta())
+	}
+	return cmp.Options{
+		cmp.Comparer(within),
+		cmp.Comparer(func(a, b approx.Float32) bool {
+			return within(a.Float64(), b.Float64())
+		}),
+	}
+}
+
+// Exact returns an option which treats two approximate numbers as equal if
+// their values, deltas and distributions are equal.  It applies to Float64
+// and Float32.
+func Exact() cmp.Option {
+	exact := func(a, b approx.Float64) bool {
+		return a.Value() == b.Value() && a.Delta() == b.Delta() && a.Distribution() == b.Distribution()
+	}
+	return cmp.Options{
+		cmp.Comparer(exact),
+		cmp.Comparer(func(a, b approx.Float32) bool {
+			return exact(a.Float64(), b.Float64())
+		}),
+	}
+}
@@ -0,0 +1,76 @@
+package approxcmp
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/filmil/approx/pkg/approx"
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestOptions(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b                     approx.Float64
+		comparer, within3, exact bool
+	}{
+		{a: approx.New(1, 0), b: approx.New(1, 0), comparer: true, within3: true, exact: true},
+		{a: approx.New(1, 0.5), b: approx.New(2, 0.5), comparer: true, within3: true},
+		{a: approx.New(1, 0.5), b: approx.New(2.5, 0.5), within3: true},
+		{a: approx.New(1, 0.5), b: approx.New(5, 0.5)},
+		{a: approx.New(1, 0.5), b: approx.New(1, 0.5).WithDistribution(approx.Gaussian), comparer: true, within3: true},
+		{a: approx.New(math.NaN(), 0), b: approx.New(math.NaN(), 0)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v,%v", test.a, test.b), func(t *testing.T) {
+			t.Parallel()
+			for _, o := range []struct {
+				name     string
+				opt      cmp.Option
+				expected bool
+			}{
+				{"Comparer", Comparer(), test.comparer},
+				{"Within(3)", Within(3), test.within3},
+				{"Exact", Exact(), test.exact},
+			} {
+				if actual := cmp.Equal(test.a, test.b, o.opt); actual != o.expected {
+					t.Errorf("%v: expected: %v, actual: %v", o.name, o.expected, actual)
+				}
+				if actual := cmp.Equal(test.b, test.a, o.opt); actual != o.expected {
+					t.Errorf("%v, swapped: expected: %v, actual: %v", o.name, o.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestStruct(t *testing.T) {
+	t.Parallel()
+	type result struct {
+		Name   string
+		X      approx.Float64
+		Y      []approx.Float32
+		Params map[string]approx.Float64
+	}
+	want := result{
+		Name:   "fit",
+		X:      approx.New(1, 0.1),
+		Y:      []approx.Float32{approx.NewNumber[float32](2, 0.1)},
+		Params: map[string]approx.Float64{"a": approx.New(3, 0.2)},
+	}
+	got := result{
+		Name:   "fit",
+		X:      approx.New(1.05, 0.1),
+		Y:      []approx.Float32{approx.NewNumber[float32](2.1, 0.1)},
+		Params: map[string]approx.Float64{"a": approx.New(3.1, 0.2)},
+	}
+	if diff := cmp.Diff(want, got, Comparer()); diff != "" {
+		t.Errorf("unexpected diff (-want +got):\n%v", diff)
+	}
+	got.Params["a"] = approx.New(4, 0.2)
+	if cmp.Equal(want, got, Comparer()) {
+		t.Errorf("expected a difference in Params")
+	}
+}
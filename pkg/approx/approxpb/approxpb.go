@@ -0,0 +1,158 @@
+// Package approxpb is the protocol buffer form of approximate numbers, for
+// services that exchange measurement data.
+//
+// The message is defined in approx.proto, next to this file.  Number is its
+// Go form, with a handwritten codec which encodes to and decodes from the
+// protocol buffer wire format without depending on a protocol buffer runtime.
+// Number is therefore not a proto.Message, and can not be used with gRPC or
+// other protocol buffer APIs directly.  For those, generate code from
+// approx.proto with protoc-gen-go, into a package of your own, and convert
+// through the value, delta and distribution fields, or through the wire
+// format.
+//
+// Example:
+//
+//     data, err := approxpb.ToProto(approx.New(4.2, 0.3)).Marshal()
+//     // ...
+//     var n approxpb.Number
+//     err = n.Unmarshal(data)
+//     f := approxpb.FromProto(&n) // 4.2±0.3
+package approxpb
+
+import (
+	"encoding/binary"
+	"errors"
+	"fmt"
+	"math"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+// ErrWireFormat is returned when decoding malformed wire data.
+var ErrWireFormat = errors.New("malformed protocol buffer")
+
+// The field numbers of the message Number.
+const (
+	fieldValue        = 1
+	fieldDelta        = 2
+	fieldDistribution = 3
+)
+
+// maxField is the largest valid field number.
+const maxField = 1<<29 - 1
+
+// The wire types of the protocol buffer encoding.
+const (
+	wireVarint  = 0
+	wireFixed64 = 1
+	wireBytes   = 2
+	wireFixed32 = 5
+)
+
+// Number is the message Number of approx.proto.
+type Number struct {
+	Value        float64
+	Delta        float64
+	Distribution approx.Distribution
+}
+
+// ToProto converts f to its protocol buffer form.
+func ToProto(f approx.Float64) *Number {
+	return &Number{Value: f.Value(), Delta: f.Delta(), Distribution: f.Distribution()}
+}
+
+// FromProto converts n to an approximate number.  A nil n converts to zero.
+func FromProto(n *Number) approx.Float64 {
+	if n == nil {
+		return approx.Float64{}
+	}
+	return approx.New(n.Value, n.Delta).WithDistribution(n.Distribution)
+}
+
+// Marshal encodes n in the protocol buffer wire format.  As in proto3, fields
+// with zero values are omitted.
+func (n *Number) Marshal() ([]byte, error) {
+	var data []byte
+	for _, f := range []struct {
+		num int
+		val float64
+	}{{fieldValue, n.Value}, {fieldDelta, n.Delta}} {
+		if bits := math.Float64bits(f.val); bits != 0 {
+			data = appendVarint(data, uint64(f.num<<3|wireFixed64))
+			data = appendFixed64(data, bits)
+		}
+	}
+	if n.Distribution != 0 {
+		data = appendVarint(data, fieldDistribution<<3|wireVarint)
+		data = appendVarint(data, uint64(n.Distribution))
+	}
+	return data, nil
+}
+
+// appendVarint appends the varint encoding of v to data.
+func appendVarint(data []byte, v uint64) []byte {
+	var buf [binary.MaxVarintLen64]byte
+	return append(data, buf[:binary.PutUvarint(buf[:], v)]...)
+}
+
+// appendFixed64 appends the little endian encoding of v to data.
+func appendFixed64(data []byte, v uint64) []byte {
+	var buf [8]byte
+	binary.LittleEndian.PutUint64(buf[:], v)
+	return append(data, buf[:]...)
+}
+
+// Unmarshal decodes n from the protocol buffer wire format.  Unknown fields
+// are skipped, for compatibility with later versions of the message.  Returns
+// an error wrapping ErrWireFormat if data is malformed.
+func (n *Number) Unmarshal(data []byte) error {
+	*n = Number{}
+	for len(data) > 0 {
+		tag, k := binary.Uvarint(data)
+		if k <= 0 {
+			return fmt.Errorf("could not decode tag: %w", ErrWireFormat)
+		}
+		data = data[k:]
+		num, wire := tag>>3, tag&7
+		if num == 0 || num > maxField {
+			return fmt.Errorf("invalid field number %v: %w", num, ErrWireFormat)
+		}
+		switch wire {
+		case wireVarint:
+			v, k := binary.Uvarint(data)
+			if k <= 0 {
+				return fmt.Errorf("could not decode varint of field %v: %w", num, ErrWireFormat)
+			}
+			data = data[k:]
+			if num == fieldDistribution {
+				n.Distribution = approx.Distribution(int32(v))
+			}
+		case wireFixed64:
+			if len(data) < 8 {
+				return fmt.Errorf("could not decode fixed64 of field %v: %w", num, ErrWireFormat)
+			}
+			v := math.Float64frombits(binary.LittleEndian.Uint64(data))
+			data = data[8:]
+			switch num {
+			case fieldValue:
+				n.Value = v
+			case fieldDelta:
+				n.Delta = v
+			}
+		case wireBytes:
+			l, k := binary.Uvarint(data)
+			if k <= 0 || uint64(len(data)-k) < l {
+				return fmt.Errorf("could not decode bytes of field %v: %w", num, ErrWireFormat)
+			}
+			data = data[k+int(l):]
+		case wireFixed32:
+			if len(data) < 4 {
+				return fmt.Errorf("could not decode fixed32 of field %v: %w", num, ErrWireFormat)
+			}
+			data = data[4:]
+		default:
+			return fmt.Errorf("unsupported wire type %v of field %v: %w", wire, num, ErrWireFormat)
+		}
+	}
+	return nil
+}
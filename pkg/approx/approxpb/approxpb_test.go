@@ -0,0 +1,124 @@
+package approxpb
+
+import (
+	"bytes"
+	"errors"
+	"math"
+	"testing"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+func TestMarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		n        Number
+		expected []byte
+	}{
+		{name: "zero", n: Number{}, expected: nil},
+		{
+			name:     "value",
+			n:        Number{Value: 1},
+			expected: []byte{0x09, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f},
+		},
+		{
+			name: "all",
+			n:    Number{Value: 1, Delta: 0.5, Distribution: approx.Uniform},
+			expected: []byte{
+				0x09, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f,
+				0x11, 0, 0, 0, 0, 0, 0, 0xe0, 0x3f,
+				0x18, 0x02,
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := test.n.Marshal()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !bytes.Equal(actual, test.expected) {
+				t.Errorf("expected: %x, actual: %x", test.expected, actual)
+			}
+			var n Number
+			if err := n.Unmarshal(actual); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if n != test.n {
+				t.Errorf("round trip: expected: %+v, actual: %+v", test.n, n)
+			}
+		})
+	}
+}
+
+func TestUnmarshal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		data     []byte
+		expected Number
+		err      error
+	}{
+		{
+			name: "unknown fields",
+			data: []byte{
+				0x20, 0x96, 0x01, // field 4, varint 150
+				0x2a, 0x02, 'h', 'i', // field 5, bytes "hi"
+				0x35, 1, 2, 3, 4, // field 6, fixed32
+				0x39, 1, 2, 3, 4, 5, 6, 7, 8, // field 7, fixed64
+				0x09, 0, 0, 0, 0, 0, 0, 0xf0, 0x3f,
+			},
+			expected: Number{Value: 1},
+		},
+		{name: "truncated tag", data: []byte{0x80}, err: ErrWireFormat},
+		{name: "truncated fixed64", data: []byte{0x09, 0, 0}, err: ErrWireFormat},
+		{name: "truncated varint", data: []byte{0x18}, err: ErrWireFormat},
+		{name: "truncated bytes", data: []byte{0x2a, 0x05, 'h'}, err: ErrWireFormat},
+		{name: "truncated fixed32", data: []byte{0x35, 1}, err: ErrWireFormat},
+		{name: "group", data: []byte{0x23}, err: ErrWireFormat},
+		{name: "field zero", data: []byte{0x01, 0, 0, 0, 0, 0, 0, 0, 0}, err: ErrWireFormat},
+		{name: "field too large", data: []byte{0x80, 0x80, 0x80, 0x80, 0x10, 0}, err: ErrWireFormat},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			n := Number{Value: 42}
+			err := n.Unmarshal(test.data)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if err == nil && n != test.expected {
+				t.Errorf("expected: %+v, actual: %+v", test.expected, n)
+			}
+		})
+	}
+}
+
+func TestConvert(t *testing.T) {
+	t.Parallel()
+	tests := []approx.Float64{
+		approx.New(4.2, 0.3),
+		approx.New(-1, 0).WithDistribution(approx.Gaussian),
+		approx.New(math.Inf(1), 0),
+	}
+	for _, f := range tests {
+		data, err := ToProto(f).Marshal()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var n Number
+		if err := n.Unmarshal(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual := FromProto(&n); actual != f {
+			t.Errorf("expected: %v, actual: %v", f, actual)
+		}
+	}
+	if actual := FromProto(nil); actual != (approx.Float64{}) {
+		t.Errorf("expected zero, got: %v", actual)
+	}
+}
@@ -0,0 +1,85 @@
+// Package approxtest provides test assertions for verifying results, such as
+// the outputs of simulations, against approximate numbers, such as measured
+// values with their tolerances.
+//
+// Example:
+//
+//     func TestSimulation(t *testing.T) {
+//         measured := approx.New(4.2, 0.3)
+//         approxtest.AssertWithin(t, simulate(), measured)
+//     }
+package approxtest
+
+import (
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+// AssertWithin reports an error if got is outside the interval of want, and
+// returns true if it is within.  The interval is widened by a few ulps, to
+// allow for the rounding of its endpoints, so that e.g. 3.9 is within
+// 4.2±0.3.  The message shows the interval, and by how much got misses it:
+//
+//     4.6 is not within 4.2±0.3:
+//       want: [3.9, 4.5]
+//       got:  4.6 (0.1 above the maximum, 1.33 deltas off the value)
+func AssertWithin(t testing.TB, got float64, want approx.Float64) bool {
+	t.Helper()
+	if math.Abs(got-want.Value()) <= want.Delta()+slack(got, want.Value()) {
+		return true
+	}
+	miss, side := want.Min()-got, "below the minimum"
+	if got > want.Max() {
+		miss, side = got-want.Max(), "above the maximum"
+	}
+	t.Errorf("%v is not within %v:\n  want: %v\n  got:  %v (%v %v, %v deltas off the value)",
+		got, want, interval(want), got, round(miss), side, round(math.Abs(got-want.Value())/want.Delta()))
+	return false
+}
+
+// AssertOverlap reports an error if the intervals of a and b do not overlap,
+// and returns true if they do.  Intervals which only touch overlap, allowing
+// for the rounding of their endpoints as in AssertWithin.  The message shows
+// both intervals, and the gap between them:
+//
+//     1±0.1 and 2±0.1 do not overlap:
+//       a:   [0.9, 1.1]
+//       b:   [1.9, 2.1]
+//       gap: 0.8
+func AssertOverlap(t testing.TB, a, b approx.Float64) bool {
+	t.Helper()
+	gap := math.Abs(a.Value()-b.Value()) - a.Delta() - b.Delta()
+	if gap <= slack(a.Value(), b.Value()) {
+		return true
+	}
+	t.Errorf("%v and %v do not overlap:\n  a:   %v\n  b:   %v\n  gap: %v",
+		a, b, interval(a), interval(b), round(gap))
+	return false
+}
+
+// slack returns the allowance for the rounding of the interval endpoints near
+// x and y.
+func slack(x, y float64) float64 {
+	return 4 * epsilon * math.Max(math.Abs(x), math.Abs(y))
+}
+
+// epsilon is the difference between 1 and the next float64.
+const epsilon = 0x1p-52
+
+// interval formats the interval of f with 15 significant digits, which hides
+// the rounding of its endpoints.
+func interval(f approx.Float64) string {
+	return fmt.Sprintf("[%.15g, %.15g]", f.Min(), f.Max())
+}
+
+// round rounds x to 3 significant digits, for messages.
+func round(x float64) float64 {
+	if x == 0 || math.IsNaN(x) || math.IsInf(x, 0) {
+		return x
+	}
+	scale := math.Pow10(int(math.Floor(math.Log10(math.Abs(x)))) - 2)
+	return math.Round(x/scale) * scale
+}
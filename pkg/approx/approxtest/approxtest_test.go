@@ -0,0 +1,117 @@
+package approxtest
+
+import (
+	"fmt"
+	"testing"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+// fakeT records the errors reported through it.
+type fakeT struct {
+	testing.TB
+	errors []string
+}
+
+func (t *fakeT) Helper() {}
+
+func (t *fakeT) Errorf(format string, args ...interface{}) {
+	t.errors = append(t.errors, fmt.Sprintf(format, args...))
+}
+
+func TestAssertWithin(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		got      float64
+		want     approx.Float64
+		expected string
+	}{
+		{got: 4.2, want: approx.New(4.2, 0.3)},
+		{got: 4.5, want: approx.New(4.2, 0.3)},
+		{got: 3.9, want: approx.New(4.2, 0.3)},
+		{got: 4.2, want: approx.New(4.2, 0)},
+		{
+			got:  4.6,
+			want: approx.New(4.2, 0.3),
+			expected: "4.6 is not within 4.2±0.3:\n" +
+				"  want: [3.9, 4.5]\n" +
+				"  got:  4.6 (0.1 above the maximum, 1.33 deltas off the value)",
+		},
+		{
+			got:  -1,
+			want: approx.New(1, 0.5),
+			expected: "-1 is not within 1±0.5:\n" +
+				"  want: [0.5, 1.5]\n" +
+				"  got:  -1 (1.5 below the minimum, 4 deltas off the value)",
+		},
+		{
+			got:  1.5,
+			want: approx.New(1, 0),
+			expected: "1.5 is not within 1±0:\n" +
+				"  want: [1, 1]\n" +
+				"  got:  1.5 (0.5 above the maximum, +Inf deltas off the value)",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v in %v", test.got, test.want), func(t *testing.T) {
+			ft := &fakeT{}
+			ok := AssertWithin(ft, test.got, test.want)
+			if ok != (test.expected == "") {
+				t.Errorf("unexpected result: %v", ok)
+			}
+			checkErrors(t, ft.errors, test.expected)
+		})
+	}
+}
+
+func TestAssertOverlap(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b     approx.Float64
+		expected string
+	}{
+		{a: approx.New(1, 0.5), b: approx.New(1.2, 0.1)},
+		{a: approx.New(1, 0.5), b: approx.New(2, 0.5)},
+		{a: approx.New(2, 0.5), b: approx.New(1, 0.5)},
+		{
+			a: approx.New(1, 0.1),
+			b: approx.New(2, 0.1),
+			expected: "1±0.1 and 2±0.1 do not overlap:\n" +
+				"  a:   [0.9, 1.1]\n" +
+				"  b:   [1.9, 2.1]\n" +
+				"  gap: 0.8",
+		},
+		{
+			a: approx.New(2, 0.1),
+			b: approx.New(1, 0.1),
+			expected: "2±0.1 and 1±0.1 do not overlap:\n" +
+				"  a:   [1.9, 2.1]\n" +
+				"  b:   [0.9, 1.1]\n" +
+				"  gap: 0.8",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v and %v", test.a, test.b), func(t *testing.T) {
+			ft := &fakeT{}
+			ok := AssertOverlap(ft, test.a, test.b)
+			if ok != (test.expected == "") {
+				t.Errorf("unexpected result: %v", ok)
+			}
+			checkErrors(t, ft.errors, test.expected)
+		})
+	}
+}
+
+// checkErrors checks that errors has the single expected error, or none if
+// expected is empty.
+func checkErrors(t *testing.T, errors []string, expected string) {
+	t.Helper()
+	switch {
+	case expected == "" && len(errors) > 0:
+		t.Errorf("unexpected errors: %q", errors)
+	case expected != "" && (len(errors) != 1 || errors[0] != expected):
+		t.Errorf("expected error:\n%v\nactual: %q", expected, errors)
+	}
+}
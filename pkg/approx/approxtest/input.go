@@ -0,0 +1,58 @@
+package approxtest
+
+import (
+	"fmt"
+	"math/rand"
+	"strings"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+// ValidInput returns a random string which approx.Parse accepts, in any of
+// the forms it accepts, for property tests and fuzzing of code that reads
+// approximate numbers.
+//
+// Example, seeding a fuzz test:
+//
+//     r := rand.New(rand.NewSource(1))
+//     for i := 0; i < 100; i++ {
+//         f.Add(approxtest.ValidInput(r))
+//     }
+func ValidInput(r *rand.Rand) string {
+	f := approx.Float64{}.Generate(r, 100).Interface().(approx.Float64)
+	forms := []func() string{
+		f.String,
+		func() string { return fmt.Sprintf("%v +/- %v", f.Value(), f.Delta()) },
+		func() string { return fmt.Sprintf("%v+-%v", f.Value(), f.Delta()) },
+		func() string { return fmt.Sprintf("%.3e ± %.1e", f.Value(), f.Delta()) },
+		func() string { return fmt.Sprintf("%v ± %v%%", f.Value(), r.Intn(10)) },
+		func() string { return f.Concise(1 + r.Intn(3)) },
+		func() string { return f.FormatEng(1 + r.Intn(3)) },
+		f.IntervalString,
+		func() string { return fmt.Sprintf("~%.*f", r.Intn(4), f.Value()) },
+	}
+	return forms[r.Intn(len(forms))]()
+}
+
+// InvalidInput returns a random string which approx.Parse rejects, made by
+// corrupting a ValidInput.
+func InvalidInput(r *rand.Rand) string {
+	corruptions := []func(s string) string{
+		func(s string) string { return s + "x" },
+		func(s string) string { return "x" + s },
+		func(s string) string { return s + "±" },
+		func(s string) string { return s + "±1±1" },
+		func(s string) string { return s[:r.Intn(len(s))] },
+		func(s string) string {
+			i := r.Intn(len(s))
+			return s[:i] + "#" + s[i:]
+		},
+		func(s string) string { return strings.Repeat(s, 2) },
+	}
+	for {
+		s := corruptions[r.Intn(len(corruptions))](ValidInput(r))
+		if _, err := approx.Parse(s); err != nil {
+			return s
+		}
+	}
+}
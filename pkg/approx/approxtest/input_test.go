@@ -0,0 +1,30 @@
+package approxtest
+
+import (
+	"math/rand"
+	"testing"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+func TestValidInput(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		s := ValidInput(r)
+		if _, err := approx.Parse(s); err != nil {
+			t.Errorf("expected valid input: %q, got: %v", s, err)
+		}
+	}
+}
+
+func TestInvalidInput(t *testing.T) {
+	t.Parallel()
+	r := rand.New(rand.NewSource(1))
+	for i := 0; i < 1000; i++ {
+		s := InvalidInput(r)
+		if f, err := approx.Parse(s); err == nil {
+			t.Errorf("expected invalid input: %q, got: %v", s, f)
+		}
+	}
+}
@@ -0,0 +1,163 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+	"regexp"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Asym is an approximate number with asymmetric uncertainties: its interval
+// reaches further above its value than below it, or the other way around.
+// Such uncertainties are common e.g. in the results of fits.
+//
+// Example:
+//     a, _ := approx.ParseAsym("5.2 +0.3 -0.1")
+//     a.Min() // 5.1
+//     a.Max() // 5.5
+type Asym struct {
+	val float64
+	// plus and minus are nonnegative.
+	plus, minus float64
+}
+
+// NewAsym constructs a new Asym with value val, whose interval reaches plus
+// above, and minus below val.
+func NewAsym(val, plus, minus float64) Asym {
+	return Asym{val: val, plus: math.Abs(plus), minus: math.Abs(minus)}
+}
+
+// signedNumber matches a number at the start of a string, with an optional
+// sign.
+var signedNumber = regexp.MustCompile(`^[+-]?(\d+\.?\d*|\.\d+)([eE][+-]?\d+)?`)
+
+// ParseAsym parses an approximate number with asymmetric uncertainties, given
+// as the value followed by the signed upper and lower deltas, in either
+// order, e.g. "5.2 +0.3 -0.1" or "5.2 +0.3/-0.1".  The LaTeX form
+// "5.2^{+0.3}_{-0.1}" is accepted as well.  Symmetric numbers in the forms
+// accepted by Parse are also accepted.  As with Parse, the errors are of type
+// *ParseError.
+func ParseAsym(s string) (Asym, error) {
+	if f, err := Parse(s); err == nil {
+		return Asym{val: f.val, plus: f.delta, minus: f.delta}, nil
+	}
+	// r is s without the ignored runes, and at[i] is the offset in s of the
+	// byte i of r.
+	var r strings.Builder
+	var at []int
+	for i := 0; i < len(s); {
+		c, n := utf8.DecodeRuneInString(s[i:])
+		if !unicode.IsSpace(c) && !strings.ContainsRune("^_{}/", c) {
+			r.WriteString(s[i : i+n])
+			for k := i; k < i+n; k++ {
+				at = append(at, k)
+			}
+		}
+		i += n
+	}
+	at = append(at, len(s))
+	// nums are the offsets in r of the numbers, and of the end of r.
+	var nums []int
+	rest := r.String()
+	for p := 0; p < len(rest); {
+		n := signedNumber.FindString(rest[p:])
+		if n == "" {
+			return Asym{}, parseError(s, at[p], len(s), ErrSyntax)
+		}
+		nums = append(nums, p)
+		p += len(n)
+	}
+	nums = append(nums, len(rest))
+	if len(nums) < 3 {
+		return Asym{}, parseError(s, 0, len(s), ErrSyntax)
+	}
+	if len(nums) != 4 || !(rest[nums[1]] == '+' && rest[nums[2]] == '-' || rest[nums[1]] == '-' && rest[nums[2]] == '+') {
+		return Asym{}, parseError(s, at[nums[1]], len(s), ErrSyntax)
+	}
+	var a Asym
+	for i, p := range []*float64{&a.val, &a.plus, &a.minus} {
+		v, err := strconv.ParseFloat(rest[nums[i]:nums[i+1]], 64)
+		if err != nil {
+			kind := ErrBadDelta
+			if i == 0 {
+				kind = ErrBadValue
+			}
+			return Asym{}, parseError(s, at[nums[i]], at[nums[i+1]-1]+1, kind)
+		}
+		*p = math.Abs(v)
+		if i == 0 {
+			*p = v
+		}
+	}
+	if rest[nums[1]] == '-' {
+		a.plus, a.minus = a.minus, a.plus
+	}
+	return a, nil
+}
+
+// Value returns the value of a.
+func (a Asym) Value() float64 {
+	return a.val
+}
+
+// Plus returns how far the interval of a reaches above its value.
+func (a Asym) Plus() float64 {
+	return a.plus
+}
+
+// Minus returns how far the interval of a reaches below its value.
+func (a Asym) Minus() float64 {
+	return a.minus
+}
+
+// Min returns the minimal extreme value for a.
+func (a Asym) Min() float64 {
+	return a.val - a.minus
+}
+
+// Max returns the maximal extreme value for a.
+func (a Asym) Max() float64 {
+	return a.val + a.plus
+}
+
+// String implements Stringer.  Numbers with symmetric uncertainties are
+// printed the same as Float64 numbers.
+func (a Asym) String() string {
+	if a.plus == a.minus {
+		return fmt.Sprintf("%v±%v", a.val, a.plus)
+	}
+	return fmt.Sprintf("%v +%v -%v", a.val, a.plus, a.minus)
+}
+
+// Float64 converts a to a Float64 with the same interval.  The value of the
+// result is the center of the interval, not the value of a.
+func (a Asym) Float64() Float64 {
+	return fromMinMax(a.Min(), a.Max())
+}
+
+// Add computes a+b.
+func (a Asym) Add(b Asym) Asym {
+	return Asym{val: a.val + b.val, plus: a.plus + b.plus, minus: a.minus + b.minus}
+}
+
+// Sub computes a-b.  The upper delta of b lowers the difference, and the
+// other way around.
+func (a Asym) Sub(b Asym) Asym {
+	return a.Add(b.Neg())
+}
+
+// Neg computes -a.
+func (a Asym) Neg() Asym {
+	return Asym{val: -a.val, plus: a.minus, minus: a.plus}
+}
+
+// Mul computes c*a for an exact number c.
+func (a Asym) Mul(c float64) Asym {
+	if c < 0 {
+		return a.Neg().Mul(-c)
+	}
+	return Asym{val: c * a.val, plus: c * a.plus, minus: c * a.minus}
+}
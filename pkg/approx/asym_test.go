@@ -0,0 +1,114 @@
+package approx
+
+import (
+	"errors"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestParseAsym(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected Asym
+		err      bool
+	}{
+		{input: "5.2 +0.3 -0.1", expected: NewAsym(5.2, 0.3, 0.1)},
+		{input: "5.2+0.3-0.1", expected: NewAsym(5.2, 0.3, 0.1)},
+		{input: "5.2 -0.1 +0.3", expected: NewAsym(5.2, 0.3, 0.1)},
+		{input: "5.2 +0.3/-0.1", expected: NewAsym(5.2, 0.3, 0.1)},
+		{input: "-5.2 +0.3 -0.1", expected: NewAsym(-5.2, 0.3, 0.1)},
+		{input: "1e3 +2e1 -1e+1", expected: NewAsym(1000, 20, 10)},
+		{input: "5.2^{+0.3}_{-0.1}", expected: NewAsym(5.2, 0.3, 0.1)},
+		{input: "5.2_{-0.1}^{+0.3}", expected: NewAsym(5.2, 0.3, 0.1)},
+		{input: "5.2±0.3", expected: NewAsym(5.2, 0.3, 0.3)},
+		{input: "5.2 +/- 0.3", expected: NewAsym(5.2, 0.3, 0.3)},
+		{input: "5.2", expected: NewAsym(5.2, 0, 0)},
+		{input: "5.2 +0.3", err: true},
+		{input: "5.2 +0.3 +0.1", err: true},
+		{input: "5.2 +0.3 0.1", err: true},
+		{input: "5.2 -0.3 -0.1", err: true},
+		{input: "5.2 +0.3 -0.1 +1", err: true},
+		{input: "5.2 +x -0.1", err: true},
+		{input: "", err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			actual, err := ParseAsym(test.input)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !cmp.Equal(actual, test.expected, cmp.AllowUnexported(Asym{})) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseAsymError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected ParseError
+	}{
+		{input: "5.2 +x -0.1", expected: ParseError{Offset: 4, Text: "+x -0.1", Err: ErrSyntax}},
+		{input: "5.2 +0.3", expected: ParseError{Offset: 4, Text: "+0.3", Err: ErrSyntax}},
+		{input: "5.2/", expected: ParseError{Offset: 0, Text: "5.2/", Err: ErrSyntax}},
+		{input: "5.2 +0.3 +0.1", expected: ParseError{Offset: 4, Text: "+0.3 +0.1", Err: ErrSyntax}},
+		{input: "5.2^{+0.3}_{+0.1}", expected: ParseError{Offset: 5, Text: "+0.3}_{+0.1}", Err: ErrSyntax}},
+		{input: "1e999 +0.3 -0.1", expected: ParseError{Offset: 0, Text: "1e999", Err: ErrBadValue}},
+		{input: "5.2 +0.3 -1e999", expected: ParseError{Offset: 9, Text: "-1e999", Err: ErrBadDelta}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := ParseAsym(test.input)
+			var actual *ParseError
+			if !errors.As(err, &actual) {
+				t.Fatalf("expected a ParseError, got: %v", err)
+			}
+			test.expected.Input = test.input
+			if *actual != test.expected {
+				t.Errorf("expected: %+v, actual: %+v", test.expected, *actual)
+			}
+			if !errors.Is(err, test.expected.Err) {
+				t.Errorf("expected errors.Is(err, %v)", test.expected.Err)
+			}
+		})
+	}
+}
+
+func TestAsym(t *testing.T) {
+	t.Parallel()
+	a := NewAsym(5, 0.3, -0.1)
+	b := NewAsym(2, 0.5, 0.25)
+	tests := []struct {
+		name     string
+		actual   interface{}
+		expected interface{}
+	}{
+		{name: "string", actual: a.String(), expected: "5 +0.3 -0.1"},
+		{name: "symmetric string", actual: NewAsym(5, 1, 1).String(), expected: "5±1"},
+		{name: "accessors", actual: []float64{a.Value(), a.Plus(), a.Minus()}, expected: []float64{5, 0.3, 0.1}},
+		{name: "min max", actual: []float64{a.Min(), a.Max()}, expected: []float64{4.9, 5.3}},
+		{name: "add", actual: a.Add(b).String(), expected: "7 +0.8 -0.35"},
+		{name: "sub", actual: a.Sub(b).String(), expected: "3 +0.55 -0.6"},
+		{name: "neg", actual: a.Neg().String(), expected: "-5 +0.1 -0.3"},
+		{name: "mul", actual: b.Mul(2).String(), expected: "4 +1 -0.5"},
+		{name: "mul negative", actual: b.Mul(-2).String(), expected: "-4 +0.5 -1"},
+		{name: "float64", actual: NewAsym(5, 0.5, 0.25).Float64().String(), expected: "5.125±0.375"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if !cmp.Equal(test.actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
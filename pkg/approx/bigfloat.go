@@ -0,0 +1,258 @@
+package approx
+
+import (
+	"fmt"
+	"math/big"
+)
+
+// BigFloat is an approximate number whose value and delta are arbitrary
+// precision floating point numbers.
+//
+// Use BigFloat when the float64 rounding error is comparable to the
+// measurement error being tracked, e.g. for very precise measurements of
+// large quantities.  The rounding error of each operation is added to the
+// delta, so the delta accounts for both.
+//
+// The uncertainty is propagated in the worst case manner, same as by
+// WorstCase.  The precision of a result is the larger of the precisions of
+// the operands.
+//
+// Example:
+//     a, _ := approx.ParseBig("299792458.000000001±0.000000001", 100)
+//     b := approx.NewBig(big.NewFloat(2), new(big.Float), 100)
+//     a.MulA(b) // 5.99584916000000002e+08±2.00000000000084703294725430034e-09
+type BigFloat struct {
+	val, delta *big.Float
+}
+
+// NewBig constructs a BigFloat with value val and delta, with the precision
+// prec in bits.  The arguments are copied.
+func NewBig(val, delta *big.Float, prec uint) BigFloat {
+	return BigFloat{
+		val:   newBig(prec, big.ToNearestEven).Set(val),
+		delta: newBig(prec, big.AwayFromZero).Abs(delta),
+	}
+}
+
+// BigFromFloat64 converts f into a BigFloat with the precision prec in bits.
+// The conversion is exact if prec is at least 53.
+func BigFromFloat64(f Float64, prec uint) BigFloat {
+	return NewBig(big.NewFloat(f.val), big.NewFloat(f.delta), prec)
+}
+
+// ParseBig parses an approximate number from a string, in any of the forms
+// accepted by Parse, into a BigFloat with the precision prec in bits.  The
+// numbers are converted from their decimal text, not via float64, and the
+// delta is widened to cover their rounding.
+//
+// Example:
+//     approx.ParseBig("4.2±0.3", 64)
+//     approx.ParseBig("1.234(5)e-3", 64)
+//
+// The returned error is a *ParseError.
+func ParseBig(s string, prec uint) (BigFloat, error) {
+	return parseLiteral(s, func(l literal, s string) (BigFloat, error) {
+		return l.bigFloat(s, prec)
+	})
+}
+
+// bigFloat converts l, scanned from s, to a BigFloat with the precision prec.
+func (l literal) bigFloat(s string, prec uint) (BigFloat, error) {
+	val, _, err := big.ParseFloat(unspaced(l.val), 10, prec, big.ToNearestEven)
+	if err != nil {
+		return BigFloat{}, l.valError(s)
+	}
+	if l.exact {
+		return BigFloat{val: val, delta: new(big.Float)}.rounded(), nil
+	}
+	f := BigFloat{val: val, delta: newBig(prec, big.AwayFromZero)}
+	// The maximum of an interval is rounded as a value, rather than up.
+	mode := big.AwayFromZero
+	if l.interval {
+		mode = big.ToNearestEven
+	}
+	delta, _, err := big.ParseFloat(unspaced(l.delta), 10, prec, mode)
+	if err != nil {
+		return BigFloat{}, l.deltaError(s)
+	}
+	switch {
+	case l.interval:
+		min, max := val, delta
+		if max.Cmp(min) < 0 {
+			return BigFloat{}, parseError(s, 0, len(s), ErrBadValue)
+		}
+		f.delta.Sub(max, min)
+		f.delta.SetMantExp(f.delta, -1)
+		for _, x := range []*big.Float{min, max} {
+			if x.Acc() != big.Exact {
+				f.delta.Add(f.delta, ulp(x))
+			}
+		}
+		f.val = newBig(prec, big.ToNearestEven).Add(min, max)
+		inexact := f.val.Acc() != big.Exact
+		f.val.SetMantExp(f.val, -1)
+		if inexact {
+			f.delta.Add(f.delta, ulp(f.val))
+		}
+		return f, nil
+	case l.percent:
+		f.delta.Mul(val, delta)
+		f.delta.Quo(f.delta, big.NewFloat(100))
+	default:
+		f.delta.Set(delta)
+	}
+	f.delta.Abs(f.delta)
+	return f.rounded(), nil
+}
+
+// newBig returns a zero big.Float with precision prec and rounding mode mode.
+func newBig(prec uint, mode big.RoundingMode) *big.Float {
+	return new(big.Float).SetPrec(prec).SetMode(mode)
+}
+
+// ulp returns an upper bound for the rounding error of x, which is the unit
+// in the last place of x.
+func ulp(x *big.Float) *big.Float {
+	u := newBig(x.Prec(), big.AwayFromZero)
+	if x.Sign() == 0 || x.IsInf() {
+		return u
+	}
+	return u.SetMantExp(big.NewFloat(1), x.MantExp(nil)-int(x.Prec()))
+}
+
+// String implements Stringer.
+func (f BigFloat) String() string {
+	return fmt.Sprintf("%v±%v", f.val, f.delta)
+}
+
+// Prec returns the precision of f in bits.
+func (f BigFloat) Prec() uint {
+	return f.val.Prec()
+}
+
+// Value returns the value at the center of f's interval.
+func (f BigFloat) Value() *big.Float {
+	return new(big.Float).Copy(f.val)
+}
+
+// Delta returns the delta around the interval.  delta is nonnegative.
+func (f BigFloat) Delta() *big.Float {
+	return new(big.Float).Copy(f.delta)
+}
+
+// Min returns the minimal extreme value for f, rounded down.
+func (f BigFloat) Min() *big.Float {
+	return newBig(f.Prec(), big.ToNegativeInf).Sub(f.val, f.delta)
+}
+
+// Max returns the maximal extreme value for f, rounded up.
+func (f BigFloat) Max() *big.Float {
+	return newBig(f.Prec(), big.ToPositiveInf).Add(f.val, f.delta)
+}
+
+// Float64 converts f to a Float64, with the delta widened to cover the
+// rounding of the value to float64.
+func (f BigFloat) Float64() Float64 {
+	val, _ := f.val.Float64()
+	err := newBig(f.Prec(), big.AwayFromZero).Sub(f.val, big.NewFloat(val))
+	delta, _ := newBig(53, big.AwayFromZero).Add(f.delta, err.Abs(err)).Float64()
+	return New(val, delta)
+}
+
+// Add computes f+g.
+func (f BigFloat) Add(g BigFloat) BigFloat {
+	prec := maxPrec(f, g)
+	r := BigFloat{
+		val:   newBig(prec, big.ToNearestEven).Add(f.val, g.val),
+		delta: newBig(prec, big.AwayFromZero).Add(f.delta, g.delta),
+	}
+	return r.rounded()
+}
+
+// Sub computes f-g.
+func (f BigFloat) Sub(g BigFloat) BigFloat {
+	prec := maxPrec(f, g)
+	r := BigFloat{
+		val:   newBig(prec, big.ToNearestEven).Sub(f.val, g.val),
+		delta: newBig(prec, big.AwayFromZero).Add(f.delta, g.delta),
+	}
+	return r.rounded()
+}
+
+// MulA computes f*g.
+//
+// Based on the first order Taylor expansion:
+//   d(f*g) = |g|*df + |f|*dg
+func (f BigFloat) MulA(g BigFloat) BigFloat {
+	prec := maxPrec(f, g)
+	r := BigFloat{
+		val:   newBig(prec, big.ToNearestEven).Mul(f.val, g.val),
+		delta: sumOfProducts(prec, g.val, f.delta, f.val, g.delta),
+	}
+	return r.rounded()
+}
+
+// DivA computes f/g.  Returns an error wrapping ErrDivisorContainsZero if the
+// interval of g contains zero.
+//
+// Based on the first order Taylor expansion:
+//   d(f/g) = (df + |f/g|*dg) / |g|
+func (f BigFloat) DivA(g BigFloat) (BigFloat, error) {
+	if g.Min().Sign() <= 0 && g.Max().Sign() >= 0 {
+		return BigFloat{}, fmt.Errorf("could not divide %v by %v: %w", f, g, ErrDivisorContainsZero)
+	}
+	prec := maxPrec(f, g)
+	one := big.NewFloat(1)
+	q := newBig(prec, big.ToNearestEven).Quo(f.val, g.val)
+	delta := sumOfProducts(prec, one, f.delta, q, g.delta)
+	absG := newBig(prec, big.ToNegativeInf).Abs(g.val)
+	r := BigFloat{val: q, delta: delta.Quo(delta, absG)}
+	return r.rounded(), nil
+}
+
+// Lt returns true if f is definitely less than g.
+func (f BigFloat) Lt(g BigFloat) bool {
+	return f.Max().Cmp(g.Min()) < 0
+}
+
+// Le returns true if f is definitely either less than, or equal to g.
+func (f BigFloat) Le(g BigFloat) bool {
+	return f.Max().Cmp(g.Min()) <= 0
+}
+
+// Gt returns true if f is definitely greater than g.
+func (f BigFloat) Gt(g BigFloat) bool {
+	return g.Lt(f)
+}
+
+// Ge returns true if f is definitely either greater than, or equal to g.
+func (f BigFloat) Ge(g BigFloat) bool {
+	return g.Le(f)
+}
+
+// rounded returns f with its delta widened to cover the rounding error of
+// its value, if the value was rounded.
+func (f BigFloat) rounded() BigFloat {
+	if f.val.Acc() != big.Exact {
+		f.delta.Add(f.delta, ulp(f.val))
+	}
+	return f
+}
+
+// sumOfProducts computes |a|*b+|c|*d with precision prec, rounded up.  b and
+// d must be nonnegative.
+func sumOfProducts(prec uint, a, b, c, d *big.Float) *big.Float {
+	ab := newBig(prec, big.AwayFromZero).Abs(a)
+	ab.Mul(ab, b)
+	cd := newBig(prec, big.AwayFromZero).Abs(c)
+	cd.Mul(cd, d)
+	return ab.Add(ab, cd)
+}
+
+// maxPrec returns the larger of the precisions of f and g.
+func maxPrec(f, g BigFloat) uint {
+	if f.Prec() > g.Prec() {
+		return f.Prec()
+	}
+	return g.Prec()
+}
@@ -0,0 +1,121 @@
+package approx
+
+import (
+	"errors"
+	"math/big"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func mustBig(f BigFloat, err error) BigFloat {
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func TestParseBig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		prec     uint
+		expected string
+		err      bool
+	}{
+		{input: "0.5±0.25", prec: 64, expected: "0.5±0.25"},
+		{input: "-0.5 ± -0.25", prec: 64, expected: "-0.5±0.25"},
+		{input: "3", prec: 64, expected: "3±0"},
+		{input: "0.1", prec: 64, expected: "0.1±6.776263578034403e-21"},
+		{input: "299792458.000000001±0.000000001", prec: 100, expected: "2.99792458000000001e+08±1.00000000000042351647362715017e-09"},
+		{input: "4.2+/-0.3", prec: 64, expected: "4.2±0.30000000000000000044"},
+		{input: "4.2(3)", prec: 64, expected: "4.2±0.30000000000000000044"},
+		{input: "4.2±5%", prec: 64, expected: "4.2±0.21000000000000000044"},
+		{input: "~4.2", prec: 64, expected: "4.2±0.050000000000000000434"},
+		{input: "[0.5, 1.5]", prec: 64, expected: "1±0.5"},
+		{input: "(1.5±0.25)e2", prec: 64, expected: "150±25"},
+		{input: "[2, 1]", prec: 64, err: true},
+		{input: "abc", prec: 64, err: true},
+		{input: "1±abc", prec: 64, err: true},
+		{input: "1±2±3", prec: 64, err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			actual, err := ParseBig(test.input, test.prec)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if err == nil && actual.String() != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseBigError(t *testing.T) {
+	t.Parallel()
+	_, err := ParseBig("4.2 ± x", 64)
+	var e *ParseError
+	if !errors.As(err, &e) || !errors.Is(err, ErrBadDelta) {
+		t.Fatalf("expected a ParseError for the delta, got: %v", err)
+	}
+	if e.Offset != 7 || e.Text != "x" {
+		t.Errorf("expected x at offset 7, got: %q at offset %v", e.Text, e.Offset)
+	}
+}
+
+func TestBigOps(t *testing.T) {
+	t.Parallel()
+	a := mustBig(ParseBig("0.5±0.25", 64))
+	b := mustBig(ParseBig("2±0.5", 64))
+	tenth := mustBig(ParseBig("0.1", 64))
+	tests := []struct {
+		name     string
+		actual   BigFloat
+		expected string
+	}{
+		{name: "Add", actual: a.Add(b), expected: "2.5±0.75"},
+		{name: "Sub", actual: a.Sub(b), expected: "-1.5±0.75"},
+		{name: "MulA", actual: a.MulA(b), expected: "1±0.75"},
+		{name: "DivA", actual: mustBig(a.DivA(b)), expected: "0.25±0.1875"},
+		{name: "rounding", actual: tenth.Add(tenth).Add(tenth), expected: "0.3±4.7433845046240818988e-20"},
+		{name: "prec", actual: a.Add(NewBig(big.NewFloat(1), new(big.Float), 128)), expected: "1.5±0.25"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if actual := test.actual.String(); actual != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestBigDivByZero(t *testing.T) {
+	t.Parallel()
+	a := BigFromFloat64(New(1, 0), 64)
+	b := BigFromFloat64(New(1, 1), 64)
+	if _, err := a.DivA(b); !errors.Is(err, ErrDivisorContainsZero) {
+		t.Errorf("expected error: %v, actual: %v", ErrDivisorContainsZero, err)
+	}
+}
+
+func TestBigConversion(t *testing.T) {
+	t.Parallel()
+	f := New(0.1, 0.01)
+	if actual := BigFromFloat64(f, 53).Float64(); !cmp.Equal(actual, f, opts...) {
+		t.Errorf("expected: %v, actual: %v", f, actual)
+	}
+	tenth := mustBig(ParseBig("0.1±0", 100)).Float64()
+	if !(tenth.Min() <= 0.1 && 0.1 <= tenth.Max()) || tenth.Delta() == 0 {
+		t.Errorf("expected the rounding of 0.1 to be covered, actual: %v", tenth)
+	}
+	a, b := BigFromFloat64(New(1, 0.5), 64), BigFromFloat64(New(2, 0.5), 64)
+	if !a.Le(b) || a.Lt(b) || !b.Ge(a) || b.Gt(a) {
+		t.Errorf("comparisons of %v and %v are wrong", a, b)
+	}
+	if prec := a.MulA(BigFromFloat64(New(1, 0), 100)).Prec(); prec != 100 {
+		t.Errorf("expected precision 100, actual: %v", prec)
+	}
+}
@@ -0,0 +1,93 @@
+package approx
+
+import (
+	"bytes"
+	"fmt"
+	"io"
+	"math"
+	"text/tabwriter"
+)
+
+// BudgetEntry is a single line of an uncertainty Budget, describing one input
+// quantity.
+type BudgetEntry struct {
+	// Name is the name of the input quantity, as given to NewNamedVar.
+	Name string
+	// Value is the estimate of the input quantity.
+	Value float64
+	// StdUncertainty is the standard uncertainty of the input quantity.
+	StdUncertainty float64
+	// Distribution is the distribution of the input quantity.
+	Distribution Distribution
+	// Sensitivity is the partial derivative of the result with respect to
+	// the input quantity.
+	Sensitivity float64
+	// Contribution is the contribution of the input quantity to the combined
+	// standard uncertainty, which is |Sensitivity|*StdUncertainty.
+	Contribution float64
+}
+
+// Budget is an uncertainty budget in the style of the GUM: a table of all
+// input quantities that a result was computed from, and how each of them
+// contributes to the combined standard uncertainty of the result.
+type Budget struct {
+	// Value is the estimate of the result.
+	Value float64
+	// Entries describe the input quantities, in the order in which they
+	// were created.
+	Entries []BudgetEntry
+	// Combined is the combined standard uncertainty of the result.
+	Combined float64
+}
+
+// Budget returns the uncertainty budget of v.  Only the input quantities that
+// contribute to the uncertainty of v appear in the budget.
+//
+// Example:
+//     l := approx.NewNamedVar("length", approx.New(2, 0.01))
+//     w := approx.NewNamedVar("width", approx.New(1, 0.02))
+//     fmt.Print(l.Mul(w).Budget())
+func (v Var) Budget() Budget {
+	b := Budget{
+		Value:    v.val,
+		Entries:  make([]BudgetEntry, len(v.terms)),
+		Combined: v.StdDev(),
+	}
+	for i, t := range v.terms {
+		b.Entries[i] = BudgetEntry{
+			Name:           t.src.name,
+			Value:          t.src.val,
+			StdUncertainty: t.src.std,
+			Distribution:   t.src.dist,
+			Sensitivity:    t.coef,
+			Contribution:   math.Abs(t.coef * t.src.std),
+		}
+	}
+	return b
+}
+
+// WriteTo writes b to w as a table, one line per input quantity, followed by
+// a line with the combined standard uncertainty.  Implements io.WriterTo.
+func (b Budget) WriteTo(w io.Writer) (int64, error) {
+	var buf bytes.Buffer
+	tw := tabwriter.NewWriter(&buf, 0, 8, 2, ' ', 0)
+	fmt.Fprintln(tw, "Quantity\tValue\tStd. uncertainty\tDistribution\tSensitivity\tContribution")
+	for _, e := range b.Entries {
+		name := e.Name
+		if name == "" {
+			name = "(unnamed)"
+		}
+		fmt.Fprintf(tw, "%v\t%v\t%v\t%v\t%v\t%v\n",
+			name, e.Value, e.StdUncertainty, e.Distribution, e.Sensitivity, e.Contribution)
+	}
+	fmt.Fprintf(tw, "Result\t%v\t\t\t\t%v\n", b.Value, b.Combined)
+	tw.Flush()
+	return buf.WriteTo(w)
+}
+
+// String implements Stringer, rendering b as a table.  See WriteTo.
+func (b Budget) String() string {
+	var buf bytes.Buffer
+	b.WriteTo(&buf)
+	return buf.String()
+}
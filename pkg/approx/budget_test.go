@@ -0,0 +1,53 @@
+package approx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestBudget(t *testing.T) {
+	t.Parallel()
+	l := NewNamedVar("length", New(2, 0.01))
+	w := NewNamedVar("width", New(1, math.Sqrt(3)*0.02).WithDistribution(Uniform))
+	actual := l.Mul(w).Budget()
+	expected := Budget{
+		Value: 2,
+		Entries: []BudgetEntry{
+			{
+				Name:           "length",
+				Value:          2,
+				StdUncertainty: 0.01,
+				Sensitivity:    1,
+				Contribution:   0.01,
+			},
+			{
+				Name:           "width",
+				Value:          1,
+				StdUncertainty: 0.02,
+				Distribution:   Uniform,
+				Sensitivity:    2,
+				Contribution:   0.04,
+			},
+		},
+		Combined: math.Hypot(0.01, 0.04),
+	}
+	if !cmp.Equal(actual, expected) {
+		t.Errorf("diff: %v", cmp.Diff(expected, actual))
+	}
+}
+
+func TestBudgetString(t *testing.T) {
+	t.Parallel()
+	x := NewNamedVar("x", New(3, 0.5).WithDistribution(Gaussian))
+	y := NewVar(New(4, 1))
+	expected := "" +
+		"Quantity   Value  Std. uncertainty  Distribution  Sensitivity  Contribution\n" +
+		"x          3      0.5               gaussian      2            1\n" +
+		"(unnamed)  4      1                 unspecified   -1           1\n" +
+		"Result     2                                                   1.4142135623730951\n"
+	if actual := x.Scale(2).Sub(y).Budget().String(); actual != expected {
+		t.Errorf("expected:\n%v\nactual:\n%v", expected, actual)
+	}
+}
@@ -0,0 +1,215 @@
+package approx
+
+import (
+	binenc "encoding/binary"
+	"fmt"
+	"hash/fnv"
+	"math"
+)
+
+// Ordering is the result of comparing two approximate numbers with Cmp.
+type Ordering int
+
+const (
+	// Less means that the first number is definitely less than the second.
+	Less Ordering = -1
+	// Equal means that both numbers are exact, and equal.
+	Equal Ordering = 0
+	// Greater means that the first number is definitely greater than the
+	// second.
+	Greater Ordering = 1
+	// Indeterminate means that the intervals of the numbers overlap or
+	// touch, so that either may be the larger one.
+	Indeterminate Ordering = 2
+)
+
+// String implements Stringer.
+func (o Ordering) String() string {
+	switch o {
+	case Less:
+		return "less"
+	case Equal:
+		return "equal"
+	case Greater:
+		return "greater"
+	case Indeterminate:
+		return "indeterminate"
+	default:
+		return fmt.Sprintf("Ordering(%d)", int(o))
+	}
+}
+
+// Cmp compares a and b by their intervals.  It returns Less if a is definitely
+// less than b, Greater if a is definitely greater than b, Equal if both are
+// exact and equal, and Indeterminate otherwise, including when either is NaN.
+// Like Lt, Cmp respects the distributions of a and b.
+// Unlike a pair of calls to Lt and Gt, which may both be false, Cmp makes the
+// undecidable case explicit.
+//
+// Example:
+//     switch approx.Cmp(measured, limit) {
+//     case approx.Less:
+//         // Within the limit.
+//     case approx.Indeterminate:
+//         // Measure again, with a better instrument.
+//     default:
+//         // Over the limit.
+//     }
+func Cmp(a, b Float64) Ordering {
+	switch {
+	case a.Lt(b):
+		return Less
+	case b.Lt(a):
+		return Greater
+	case a.Min() == a.Max() && a.Max() == b.Min() && b.Min() == b.Max():
+		return Equal
+	default:
+		return Indeterminate
+	}
+}
+
+// Pull returns the difference of a and b in the units of their combined
+// standard uncertainty, also known as the z-score:
+//   pull = (a-b) / sqrt(sa^2 + sb^2)
+// where sa and sb are the standard uncertainties of a and b, see StdDev.  This
+// is the usual measure of agreement between a measurement and a prediction,
+// e.g. a pull of 1 means that a is one standard deviation above b.
+//
+// If both a and b are exact, the pull is 0 if they are equal, and infinite
+// otherwise.
+func Pull(a, b Float64) float64 {
+	diff := a.val - b.val
+	if diff == 0 {
+		return 0
+	}
+	return diff / math.Hypot(a.StdDev(), b.StdDev())
+}
+
+// WithinNSigma returns true if a and b agree within n standard deviations, that
+// is if the absolute value of their pull is at most n.  See Pull.
+//
+// Example:
+//     measured := approx.New(9.79, 0.02).WithDistribution(approx.Gaussian)
+//     predicted := approx.New(9.81, 0)
+//     approx.WithinNSigma(measured, predicted, 2) // true, the pull is -1
+func WithinNSigma(a, b Float64, n float64) bool {
+	return math.Abs(Pull(a, b)) <= n
+}
+
+// ProbLt returns the probability that a is less than b, assuming that the
+// errors of a and b are independent and Gaussian, with standard deviations
+// given by StdDev:
+//   P(a < b) = Φ(-pull)
+// where Φ is the standard normal cumulative distribution function, and pull is
+// Pull(a, b).  Where Lt only answers whether a is definitely less than b, ProbLt
+// says how likely it is when the intervals overlap.
+//
+// If both a and b are exact and equal, the probability is 1/2, the limit for
+// vanishing uncertainties.
+//
+// Example:
+//     approx.ProbLt(approx.New(10, 1), approx.New(11, 1)) // ≈ 0.76
+func ProbLt(a, b Float64) float64 {
+	return math.Erfc(Pull(a, b)/math.Sqrt2) / 2
+}
+
+// ProbGt returns the probability that a is greater than b, under the same
+// assumptions as ProbLt.  It equals 1-ProbLt(a, b).
+func ProbGt(a, b Float64) float64 {
+	return ProbLt(b, a)
+}
+
+// Equals returns true if both the values and the deltas of f and t are equal
+// within the absolute tolerance atol, or the relative tolerance rtol, that is
+// if for each:
+//   |x-y| <= atol + rtol*max(|x|, |y|)
+// Unlike ==, which compares the exact bits, Equals forgives the rounding error
+// of a computation.  Unlike Overlap, it requires the uncertainties to agree
+// too.  The distributions are not compared.
+//
+// Example:
+//     a := approx.New(0.1, 0.01).Add(approx.New(0.2, 0.02))
+//     a == approx.New(0.3, 0.03)                // false
+//     a.Equals(approx.New(0.3, 0.03), 0, 1e-12) // true
+func (f Number[T]) Equals(t Number[T], atol, rtol float64) bool {
+	return closeTo(float64(f.val), float64(t.val), atol, rtol) &&
+		closeTo(float64(f.delta), float64(t.delta), atol, rtol)
+}
+
+// Equivalent returns true if f and t span the same interval, that is if they
+// have the same Min and Max.  The distributions are not compared.
+func (f Number[T]) Equivalent(t Number[T]) bool {
+	return f.Min() == t.Min() && f.Max() == t.Max()
+}
+
+// closeTo returns true if x and y are equal within the absolute tolerance
+// atol or the relative tolerance rtol.  Infinities are close only to
+// themselves, and NaN is close to nothing.
+func closeTo(x, y, atol, rtol float64) bool {
+	if x == y {
+		return true
+	}
+	return math.Abs(x-y) <= atol+rtol*math.Max(math.Abs(x), math.Abs(y))
+}
+
+// Contains returns true if x lies within the interval [f.Min(), f.Max()],
+// bounds included.  Use it e.g. to check whether a specification limit is
+// consistent with a measurement.
+func (f Number[T]) Contains(x float64) bool {
+	return f.Min() <= x && x <= f.Max()
+}
+
+// ContainsInterval returns true if the interval of g lies entirely within the
+// interval of f, bounds included.
+//
+// Example:
+//     spec := approx.New(5, 0.25)     // 5V ± 5%
+//     measured := approx.New(5.1, 0.05)
+//     spec.ContainsInterval(measured) // true
+func (f Number[T]) ContainsInterval(g Number[T]) bool {
+	return f.Min() <= g.Min() && g.Max() <= f.Max()
+}
+
+// Identical returns true if f and t have bit-identical values and deltas,
+// after canonicalization: -0 equals 0, and all NaNs equal each other.  Unlike
+// ==, Identical is an equivalence relation, which makes it suitable to
+// deduplicate numbers, and to key maps together with Hash.  The distributions
+// are not compared.
+//
+// The method is not called Equal, as go-cmp would then use it in place of
+// comparing the fields of a Number.
+func (f Number[T]) Identical(t Number[T]) bool {
+	return canonical(float64(f.val)) == canonical(float64(t.val)) &&
+		canonical(float64(f.delta)) == canonical(float64(t.delta))
+}
+
+// Hash returns a hash of f, such that numbers which are Identical have the same
+// hash.  The hash is deterministic, and does not depend on T, e.g. a Float32
+// hashes the same as the Float64 it converts to.
+//
+// Example, deduplicating measurements:
+//     seen := map[uint64][]approx.Float64{}
+//     for _, f := range fs {
+//         seen[f.Hash()] = append(seen[f.Hash()], f)
+//     }
+func (f Number[T]) Hash() uint64 {
+	var b [16]byte
+	binenc.BigEndian.PutUint64(b[:], canonical(float64(f.val)))
+	binenc.BigEndian.PutUint64(b[8:], canonical(float64(f.delta)))
+	h := fnv.New64a()
+	h.Write(b[:])
+	return h.Sum64()
+}
+
+// canonical returns the bits of x, with -0 replaced by 0, and all NaNs by
+// the same NaN.
+func canonical(x float64) uint64 {
+	switch {
+	case x == 0:
+		return 0
+	case math.IsNaN(x):
+		return math.Float64bits(math.NaN())
+	default:
+		return math.Float64bits(x)
+	}
+}
@@ -0,0 +1,317 @@
+package approx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPull(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		a, b     Float64
+		expected float64
+	}{
+		{name: "one sigma", a: New(9.79, 0.02), b: New(9.81, 0), expected: -1},
+		{name: "combined", a: New(10, 3), b: New(5, 4), expected: 1},
+		{name: "uniform", a: New(10, math.Sqrt(3)).WithDistribution(Uniform), b: New(8, 0), expected: 2},
+		{name: "equal exact", a: New(1, 0), b: New(1, 0), expected: 0},
+		{name: "different exact", a: New(1, 0), b: New(2, 0), expected: math.Inf(-1)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := Pull(test.a, test.b)
+			if math.Abs(actual-test.expected) > 1e-9 && actual != test.expected {
+				t.Errorf("Pull(%v, %v): expected: %v, actual: %v", test.a, test.b, test.expected, actual)
+			}
+			if back := Pull(test.b, test.a); back != -actual {
+				t.Errorf("expected antisymmetry: %v, %v", actual, back)
+			}
+		})
+	}
+}
+
+func TestWithinNSigma(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b     Float64
+		n        float64
+		expected bool
+	}{
+		{a: New(9.79, 0.02), b: New(9.81, 0), n: 2, expected: true},
+		{a: New(9.79, 0.02), b: New(9.81, 0), n: 0.5, expected: false},
+		{a: New(10, 3), b: New(5, 4), n: 1, expected: true},
+		{a: New(10, 3), b: New(4, 4), n: 1, expected: false},
+		{a: New(1, 0), b: New(1, 0), n: 0, expected: true},
+		{a: New(1, 0), b: New(1.5, 0), n: 100, expected: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.a.String()+","+test.b.String(), func(t *testing.T) {
+			t.Parallel()
+			if actual := WithinNSigma(test.a, test.b, test.n); actual != test.expected {
+				t.Errorf("WithinNSigma(%v, %v, %v): expected: %v, actual: %v", test.a, test.b, test.n, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestProbLt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		a, b     Float64
+		expected float64
+	}{
+		{name: "equal", a: New(10, 1), b: New(10, 2), expected: 0.5},
+		{name: "one sigma", a: New(9, 1), b: New(10, 0), expected: 0.8413447460685429},
+		{name: "combined", a: New(10, 3), b: New(5, 4), expected: 0.15865525393145707},
+		{name: "far below", a: New(0, 1), b: New(100, 1), expected: 1},
+		{name: "far above", a: New(100, 1), b: New(0, 1), expected: 0},
+		{name: "exact less", a: New(1, 0), b: New(2, 0), expected: 1},
+		{name: "exact greater", a: New(2, 0), b: New(1, 0), expected: 0},
+		{name: "exact equal", a: New(1, 0), b: New(1, 0), expected: 0.5},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			lt := ProbLt(test.a, test.b)
+			if math.Abs(lt-test.expected) > 1e-12 {
+				t.Errorf("ProbLt(%v, %v): expected: %v, actual: %v", test.a, test.b, test.expected, lt)
+			}
+			if gt := ProbGt(test.a, test.b); math.Abs(lt+gt-1) > 1e-12 {
+				t.Errorf("ProbLt + ProbGt: expected: 1, actual: %v + %v", lt, gt)
+			}
+		})
+	}
+}
+
+func TestCmp(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b     Float64
+		expected Ordering
+	}{
+		{a: New(1, 0.5), b: New(3, 0.5), expected: Less},
+		{a: New(3, 0.5), b: New(1, 0.5), expected: Greater},
+		{a: New(1, 1), b: New(3, 1), expected: Indeterminate},
+		{a: New(1, 1), b: New(1.5, 0), expected: Indeterminate},
+		{a: New(2, 0), b: New(2, 0), expected: Equal},
+		{a: New(2, 0), b: New(3, 0), expected: Less},
+		{a: New(math.NaN(), 0), b: New(1, 0), expected: Indeterminate},
+		{a: New(math.Inf(1), 0), b: New(1, 0), expected: Greater},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.a.String()+","+test.b.String(), func(t *testing.T) {
+			t.Parallel()
+			if actual := Cmp(test.a, test.b); actual != test.expected {
+				t.Errorf("Cmp(%v, %v): expected: %v, actual: %v", test.a, test.b, test.expected, actual)
+			}
+			reverse := test.expected
+			if reverse != Indeterminate {
+				reverse = -reverse
+			}
+			if back := Cmp(test.b, test.a); back != reverse {
+				t.Errorf("Cmp(%v, %v): expected: %v, actual: %v", test.b, test.a, reverse, back)
+			}
+		})
+	}
+}
+
+func TestOrderingString(t *testing.T) {
+	t.Parallel()
+	for o, expected := range map[Ordering]string{
+		Less:          "less",
+		Equal:         "equal",
+		Greater:       "greater",
+		Indeterminate: "indeterminate",
+		Ordering(5):   "Ordering(5)",
+	} {
+		if actual := o.String(); actual != expected {
+			t.Errorf("expected: %q, actual: %q", expected, actual)
+		}
+	}
+}
+
+func TestEquals(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name       string
+		f, t       Float64
+		atol, rtol float64
+		expected   bool
+	}{
+		{name: "identical", f: New(1, 0.5), t: New(1, 0.5), expected: true},
+		{name: "rounding", f: New(0.1, 0.01).Add(New(0.2, 0.02)), t: New(0.3, 0.03), rtol: 1e-12, expected: true},
+		{name: "rounding exact", f: New(0.1, 0.01).Add(New(0.2, 0.02)), t: New(0.3, 0.03), expected: false},
+		{name: "absolute", f: New(1, 0.5), t: New(1.001, 0.499), atol: 0.01, expected: true},
+		{name: "value off", f: New(1, 0.5), t: New(1.1, 0.5), atol: 0.01, expected: false},
+		{name: "delta off", f: New(1, 0.5), t: New(1, 0.6), atol: 0.01, expected: false},
+		{name: "relative", f: New(1000, 10), t: New(1001, 10.01), rtol: 1e-3, expected: true},
+		{name: "infinite", f: New(math.Inf(1), 0), t: New(math.Inf(1), 0), expected: true},
+		{name: "infinite off", f: New(math.Inf(1), 0), t: New(math.Inf(-1), 0), atol: 1, expected: false},
+		{name: "NaN", f: New(math.NaN(), 0), t: New(math.NaN(), 0), atol: 1, expected: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := test.f.Equals(test.t, test.atol, test.rtol); actual != test.expected {
+				t.Errorf("%v.Equals(%v, %v, %v): expected: %v, actual: %v", test.f, test.t, test.atol, test.rtol, test.expected, actual)
+			}
+			if actual := test.t.Equals(test.f, test.atol, test.rtol); actual != test.expected {
+				t.Errorf("expected symmetry: %v.Equals(%v)", test.t, test.f)
+			}
+		})
+	}
+}
+
+func TestEquivalent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f, t     Float64
+		expected bool
+	}{
+		{f: New(1, 0.5), t: New(1, 0.5), expected: true},
+		{f: New(1, 0.5), t: New(1, 0.5).WithDistribution(Uniform), expected: true},
+		{f: New(1, 0.5), t: New(1, 0.25), expected: false},
+		{f: New(1, 0.5), t: New(1.25, 0.25), expected: false},
+		{f: New(2, 0), t: New(2, 0), expected: true},
+		{f: New(math.NaN(), 0), t: New(math.NaN(), 0), expected: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.f.String()+","+test.t.String(), func(t *testing.T) {
+			t.Parallel()
+			if actual := test.f.Equivalent(test.t); actual != test.expected {
+				t.Errorf("%v.Equivalent(%v): expected: %v, actual: %v", test.f, test.t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestContains(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f        Float64
+		x        float64
+		expected bool
+	}{
+		{f: New(5, 0.25), x: 5, expected: true},
+		{f: New(5, 0.25), x: 4.75, expected: true},
+		{f: New(5, 0.25), x: 5.25, expected: true},
+		{f: New(5, 0.25), x: 5.3, expected: false},
+		{f: New(5, 0.25), x: -5, expected: false},
+		{f: New(5, 0), x: 5, expected: true},
+		{f: New(5, 0.25), x: math.NaN(), expected: false},
+		{f: New(math.NaN(), 1), x: 0, expected: false},
+		{f: New(0, math.Inf(1)), x: 1e300, expected: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.f.String(), func(t *testing.T) {
+			t.Parallel()
+			if actual := test.f.Contains(test.x); actual != test.expected {
+				t.Errorf("%v.Contains(%v): expected: %v, actual: %v", test.f, test.x, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestContainsInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f, g     Float64
+		expected bool
+	}{
+		{f: New(5, 0.25), g: New(5.1, 0.05), expected: true},
+		{f: New(5, 0.25), g: New(5, 0.25), expected: true},
+		{f: New(5, 0.25), g: New(5.2, 0.1), expected: false},
+		{f: New(5, 0.25), g: New(5, 0.5), expected: false},
+		{f: New(5, 0.25), g: New(5, 0), expected: true},
+		{f: New(5, 0), g: New(5, 0.25), expected: false},
+		{f: New(5, 0.25), g: New(math.NaN(), 0), expected: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.f.String()+","+test.g.String(), func(t *testing.T) {
+			t.Parallel()
+			if actual := test.f.ContainsInterval(test.g); actual != test.expected {
+				t.Errorf("%v.ContainsInterval(%v): expected: %v, actual: %v", test.f, test.g, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIdenticalHash(t *testing.T) {
+	t.Parallel()
+	negZero := math.Copysign(0, -1)
+	otherNaN := math.Float64frombits(math.Float64bits(math.NaN()) | 1)
+	tests := []struct {
+		name     string
+		f, t     Float64
+		expected bool
+	}{
+		{name: "identical", f: New(1, 0.5), t: New(1, 0.5), expected: true},
+		{name: "distribution", f: New(1, 0.5), t: New(1, 0.5).WithDistribution(Uniform), expected: true},
+		{name: "negative zero", f: New(negZero, 0.5), t: New(0, 0.5), expected: true},
+		{name: "NaN", f: New(math.NaN(), 1), t: New(otherNaN, 1), expected: true},
+		{name: "NaN delta", f: New(1, math.NaN()), t: New(1, math.NaN()), expected: true},
+		{name: "infinite", f: New(math.Inf(1), 0), t: New(math.Inf(1), 0), expected: true},
+		{name: "value", f: New(1, 0.5), t: New(math.Nextafter(1, 2), 0.5), expected: false},
+		{name: "delta", f: New(1, 0.5), t: New(1, 0.25), expected: false},
+		{name: "signs", f: New(math.Inf(1), 0), t: New(math.Inf(-1), 0), expected: false},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := test.f.Identical(test.t); actual != test.expected {
+				t.Errorf("%v.Identical(%v): expected: %v, actual: %v", test.f, test.t, test.expected, actual)
+			}
+			if actual := test.t.Identical(test.f); actual != test.expected {
+				t.Errorf("expected symmetry: %v.Identical(%v)", test.t, test.f)
+			}
+			if actual := test.f.Hash() == test.t.Hash(); actual != test.expected {
+				t.Errorf("%v.Hash() == %v.Hash(): expected: %v, actual: %v", test.f, test.t, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHashFloat32(t *testing.T) {
+	t.Parallel()
+	f := NewNumber[float32](1.5, 0.25)
+	if actual, expected := f.Hash(), f.Float64().Hash(); actual != expected {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+}
+
+// TestCmpComparesFields checks that go-cmp compares the fields of numbers,
+// which it would not if Number had an Equal method.
+func TestCmpComparesFields(t *testing.T) {
+	t.Parallel()
+	a := New(2, 1).WithDistribution(Gaussian)
+	b := New(2, 1).WithDistribution(Uniform)
+	if cmp.Equal(a, b, opts...) {
+		t.Errorf("expected %v and %v to differ in distribution", a, b)
+	}
+}
+
+func TestCmpDistribution(t *testing.T) {
+	t.Parallel()
+	a, b := New(0, 1), New(3, 1)
+	if actual := Cmp(a, b); actual != Less {
+		t.Errorf("Cmp(%v, %v): expected: %v, actual: %v", a, b, Less, actual)
+	}
+	a, b = a.WithDistribution(Gaussian), b.WithDistribution(Gaussian)
+	if actual := Cmp(a, b); actual != Indeterminate {
+		t.Errorf("Cmp(%v, %v) of Gaussians: expected: %v, actual: %v", a, b, Indeterminate, actual)
+	}
+}
@@ -0,0 +1,108 @@
+package approx
+
+import "fmt"
+
+// Complex128 is a complex number whose real and imaginary parts are
+// approximate numbers.
+//
+// This is useful for AC circuit and impedance calculations, where every
+// component has a tolerance.
+//
+// Example:
+//     // A 100Ω±1% resistor in series with a 50Ω±5% reactance.
+//     z := approx.NewComplex(approx.New(100, 1), approx.New(50, 2.5))
+//     z.Abs()   // 111.80339887498948±2.0124611797498106
+//     z.Phase() // 0.4636476090008061±0.024
+type Complex128 struct {
+	re, im Float64
+}
+
+// NewComplex constructs a Complex128 with the real part re and the imaginary
+// part im.
+func NewComplex(re, im Float64) Complex128 {
+	return Complex128{re: re, im: im}
+}
+
+// Polar constructs a Complex128 from its magnitude r and phase theta.
+func Polar(r, theta Float64) Complex128 {
+	return Complex128{re: Mul(r, Cos(theta)), im: Mul(r, Sin(theta))}
+}
+
+// Real returns the real part of z.
+func (z Complex128) Real() Float64 {
+	return z.re
+}
+
+// Imag returns the imaginary part of z.
+func (z Complex128) Imag() Float64 {
+	return z.im
+}
+
+// Value returns the complex value at the center of z's intervals.
+func (z Complex128) Value() complex128 {
+	return complex(z.re.val, z.im.val)
+}
+
+// String implements Stringer.
+func (z Complex128) String() string {
+	return fmt.Sprintf("(%v)+(%v)i", z.re, z.im)
+}
+
+// Add computes z+w.
+func (z Complex128) Add(w Complex128) Complex128 {
+	return Complex128{re: Add(z.re, w.re), im: Add(z.im, w.im)}
+}
+
+// Sub computes z-w.
+func (z Complex128) Sub(w Complex128) Complex128 {
+	return Complex128{re: Sub(z.re, w.re), im: Sub(z.im, w.im)}
+}
+
+// Mul computes z*w.
+func (z Complex128) Mul(w Complex128) Complex128 {
+	return Complex128{
+		re: Sub(Mul(z.re, w.re), Mul(z.im, w.im)),
+		im: Add(Mul(z.re, w.im), Mul(z.im, w.re)),
+	}
+}
+
+// Div computes z/w.
+//
+// Based on the first order Taylor expansion of each part of the quotient in
+// the parts of z and w.  The parts of w appear several times in the formula
+// for the quotient, which is accounted for.  The contributions are added up
+// in the worst case manner, see ApplyNGrad.
+func (z Complex128) Div(w Complex128) Complex128 {
+	q := z.Value() / w.Value()
+	den := w.re.val*w.re.val + w.im.val*w.im.val
+	re := func(x ...float64) float64 { return real(q) }
+	im := func(x ...float64) float64 { return imag(q) }
+	// The partial derivatives with respect to z.re, z.im, w.re and w.im.
+	gradRe := func(x ...float64) []float64 {
+		a, b, c, d := x[0], x[1], x[2], x[3]
+		return []float64{c / den, d / den, (a - 2*c*real(q)) / den, (b - 2*d*real(q)) / den}
+	}
+	gradIm := func(x ...float64) []float64 {
+		a, b, c, d := x[0], x[1], x[2], x[3]
+		return []float64{-d / den, c / den, (b - 2*c*imag(q)) / den, (-a - 2*d*imag(q)) / den}
+	}
+	return Complex128{
+		re: ApplyNGrad(re, gradRe, z.re, z.im, w.re, w.im),
+		im: ApplyNGrad(im, gradIm, z.re, z.im, w.re, w.im),
+	}
+}
+
+// Conj returns the complex conjugate of z.
+func (z Complex128) Conj() Complex128 {
+	return Complex128{re: z.re, im: Neg(z.im)}
+}
+
+// Abs returns the magnitude of z, see Hypot.
+func (z Complex128) Abs() Float64 {
+	return Hypot(z.re, z.im)
+}
+
+// Phase returns the phase of z, in the range [-pi, pi], see Atan2.
+func (z Complex128) Phase() Float64 {
+	return Atan2(z.im, z.re)
+}
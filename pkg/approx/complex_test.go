@@ -0,0 +1,49 @@
+package approx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestComplex(t *testing.T) {
+	t.Parallel()
+	a := NewComplex(New(1, 0.1), New(2, 0.2))
+	b := NewComplex(New(3, 0.3), New(-1, 0.1))
+	tests := []struct {
+		name     string
+		actual   Complex128
+		expected Complex128
+	}{
+		{name: "Add", actual: a.Add(b), expected: NewComplex(New(4, 0.4), New(1, 0.3))},
+		{name: "Sub", actual: a.Sub(b), expected: NewComplex(New(-2, 0.4), New(3, 0.3))},
+		{name: "Mul", actual: a.Mul(b), expected: NewComplex(New(5, 1), New(5, 1.4))},
+		{name: "Div", actual: a.Div(b), expected: NewComplex(New(0.1, 0.084), New(0.7, 0.14))},
+		{name: "Conj", actual: a.Conj(), expected: NewComplex(New(1, 0.1), New(-2, 0.2))},
+		{name: "Polar", actual: Polar(New(2, 0.1), New(0, 0.1)), expected: NewComplex(New(2, 0.10999166944394859), New(0, 0.2))},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if !near(test.actual.Real(), test.expected.Real()) || !near(test.actual.Imag(), test.expected.Imag()) {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
+
+func TestComplexPolar(t *testing.T) {
+	t.Parallel()
+	z := NewComplex(New(100, 1), New(50, 2.5))
+	if actual, expected := z.Abs(), New(math.Hypot(100, 50), 2.0124611797498106); !near(actual, expected) {
+		t.Errorf("Abs: expected: %v, actual: %v", expected, actual)
+	}
+	if actual, expected := z.Phase(), New(math.Atan2(50, 100), 0.024); !near(actual, expected) {
+		t.Errorf("Phase: expected: %v, actual: %v", expected, actual)
+	}
+	if actual := z.Value(); actual != complex(100, 50) {
+		t.Errorf("Value: expected: %v, actual: %v", complex(100, 50), actual)
+	}
+	if actual, expected := z.String(), "(100±1)+(50±2.5)i"; actual != expected {
+		t.Errorf("String: expected: %v, actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,175 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// Component is a labeled part of the uncertainty of a MultiFloat64.
+type Component struct {
+	// Label names the component, e.g. "stat" or "syst".
+	Label string
+	// Delta is the uncertainty contributed by the component.  Nonnegative.
+	Delta float64
+}
+
+// MultiFloat64 is an approximate number whose uncertainty is made up of
+// several labeled components, such as the statistical and the systematic
+// uncertainty of a measurement.
+//
+// Each component is propagated separately through the arithmetic, as if the
+// other components did not exist.  The components can be reported separately,
+// or combined at the end.
+//
+// Example:
+//     m := approx.NewMulti(10,
+//         approx.Component{Label: "stat", Delta: 0.3},
+//         approx.Component{Label: "syst", Delta: 0.4})
+//     m.String()    // 10±0.3 (stat)±0.4 (syst)
+//     m.Float64()   // 10±0.7
+type MultiFloat64 struct {
+	val float64
+	// comps is sorted by label, and has no duplicate labels.
+	comps []Component
+}
+
+// NewMulti constructs a new MultiFloat64 with value val and uncertainty
+// components comps.  Deltas of components with the same label are added up.
+func NewMulti(val float64, comps ...Component) MultiFloat64 {
+	m := MultiFloat64{val: val, comps: make([]Component, 0, len(comps))}
+	for _, c := range comps {
+		m.comps = addComponent(m.comps, c.Label, math.Abs(c.Delta))
+	}
+	return m
+}
+
+// WithLabel returns f as a MultiFloat64 whose uncertainty is a single
+// component labeled label, e.g. the name of the instrument that measured f.
+// Computing with such numbers keeps track of how much each labeled source
+// contributes to the uncertainty of the result, through any number of
+// operations.  Exact constants are NewMulti(c).
+//
+// Example:
+//     a := approx.New(20, 0.5).WithLabel("thermometer A")
+//     b := approx.New(25, 0.2).WithLabel("thermometer B")
+//     d := b.Sub(a).Mul(approx.NewMulti(2))
+//     d.Components() // [{thermometer A 1} {thermometer B 0.4}]
+func (f Number[T]) WithLabel(label string) MultiFloat64 {
+	w := f.Float64()
+	return NewMulti(w.val, Component{Label: label, Delta: w.delta})
+}
+
+// addComponent adds delta to the component labeled label in comps, keeping
+// comps sorted.
+func addComponent(comps []Component, label string, delta float64) []Component {
+	i := sort.Search(len(comps), func(i int) bool { return comps[i].Label >= label })
+	if i < len(comps) && comps[i].Label == label {
+		comps[i].Delta += delta
+		return comps
+	}
+	comps = append(comps, Component{})
+	copy(comps[i+1:], comps[i:])
+	comps[i] = Component{Label: label, Delta: delta}
+	return comps
+}
+
+// Value returns the value at the center of m's interval.
+func (m MultiFloat64) Value() float64 {
+	return m.val
+}
+
+// Component returns the delta of the component of m labeled label, or 0 if
+// there is no such component.
+func (m MultiFloat64) Component(label string) float64 {
+	i := sort.Search(len(m.comps), func(i int) bool { return m.comps[i].Label >= label })
+	if i < len(m.comps) && m.comps[i].Label == label {
+		return m.comps[i].Delta
+	}
+	return 0
+}
+
+// Components returns all uncertainty components of m, sorted by label.
+func (m MultiFloat64) Components() []Component {
+	return append([]Component(nil), m.comps...)
+}
+
+// Float64 returns m with all components combined by the current Propagator,
+// the same way as the uncertainties of a sum of independent terms: added up
+// with WorstCase, which is the default, or in quadrature with Quadrature.  The
+// total uncertainty is then the same as if m had been computed with Float64s
+// from the start.
+func (m MultiFloat64) Float64() Float64 {
+	var d float64
+	for _, c := range m.comps {
+		d = propagator.Add(New(0, d), New(0, c.Delta)).delta
+	}
+	return New(m.val, d)
+}
+
+// String implements Stringer.  Each component is printed separately,
+// followed by its label in parentheses.
+func (m MultiFloat64) String() string {
+	var b strings.Builder
+	fmt.Fprintf(&b, "%v", m.val)
+	for _, c := range m.comps {
+		fmt.Fprintf(&b, "±%v (%v)", c.Delta, c.Label)
+	}
+	return b.String()
+}
+
+// Add computes m+n.  Each component is propagated with the current
+// Propagator.
+func (m MultiFloat64) Add(n MultiFloat64) MultiFloat64 {
+	return m.op(n, propagator.Add)
+}
+
+// Sub computes m-n.  Each component is propagated with the current
+// Propagator.
+func (m MultiFloat64) Sub(n MultiFloat64) MultiFloat64 {
+	return m.op(n, propagator.Sub)
+}
+
+// Mul computes m*n.  Each component is propagated with the current
+// Propagator.
+func (m MultiFloat64) Mul(n MultiFloat64) MultiFloat64 {
+	return m.op(n, propagator.Mul)
+}
+
+// Div computes m/n.  Each component is propagated with the current
+// Propagator.
+func (m MultiFloat64) Div(n MultiFloat64) MultiFloat64 {
+	return m.op(n, propagator.Div)
+}
+
+// Apply applies the function fx to m, see Float64.Apply for details.  Each
+// component is scaled by the derivative of fx.
+func (m MultiFloat64) Apply(fx func(float64) float64, eps float64) MultiFloat64 {
+	r := MultiFloat64{val: fx(m.val), comps: make([]Component, len(m.comps))}
+	for i, c := range m.comps {
+		r.comps[i] = Component{Label: c.Label, Delta: New(m.val, c.Delta).Apply(fx, eps).delta}
+	}
+	return r
+}
+
+// op computes the binary operation op on m and n, for each uncertainty
+// component separately.
+func (m MultiFloat64) op(n MultiFloat64, op func(a, b Float64) Float64) MultiFloat64 {
+	r := MultiFloat64{
+		val:   op(New(m.val, 0), New(n.val, 0)).val,
+		comps: make([]Component, 0, len(m.comps)+len(n.comps)),
+	}
+	seen := make(map[string]bool, len(m.comps)+len(n.comps))
+	for _, comps := range [][]Component{m.comps, n.comps} {
+		for _, c := range comps {
+			if seen[c.Label] {
+				continue
+			}
+			seen[c.Label] = true
+			d := op(New(m.val, m.Component(c.Label)), New(n.val, n.Component(c.Label))).delta
+			r.comps = addComponent(r.comps, c.Label, d)
+		}
+	}
+	return r
+}
@@ -0,0 +1,134 @@
+package approx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestMultiFloat64(t *testing.T) {
+	t.Parallel()
+	a := NewMulti(10, Component{Label: "stat", Delta: 1}, Component{Label: "syst", Delta: 2})
+	b := NewMulti(5, Component{Label: "stat", Delta: 1}, Component{Label: "lumi", Delta: 0.5})
+	tests := []struct {
+		name     string
+		actual   MultiFloat64
+		expected string
+	}{
+		{
+			name:     "new",
+			actual:   a,
+			expected: "10±1 (stat)±2 (syst)",
+		},
+		{
+			name:     "duplicate labels",
+			actual:   NewMulti(1, Component{Label: "x", Delta: 1}, Component{Label: "x", Delta: -2}),
+			expected: "1±3 (x)",
+		},
+		{
+			name:     "add",
+			actual:   a.Add(b),
+			expected: "15±0.5 (lumi)±2 (stat)±2 (syst)",
+		},
+		{
+			name:     "sub",
+			actual:   a.Sub(b),
+			expected: "5±0.5 (lumi)±2 (stat)±2 (syst)",
+		},
+		{
+			name:     "mul",
+			actual:   a.Mul(b),
+			expected: "50±5 (lumi)±15.000000000000002 (stat)±10 (syst)",
+		},
+		{
+			name:     "div",
+			actual:   a.Div(b),
+			expected: "2±0.2 (lumi)±0.6000000000000001 (stat)±0.4 (syst)",
+		},
+		{
+			name: "apply",
+			actual: a.Apply(func(x float64) float64 {
+				return 3 * x
+			}, 1e-3),
+			expected: "30±3.0000000000001137 (stat)±6.000000000000227 (syst)",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if actual := test.actual.String(); actual != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+// Not parallel: changes the package-wide propagator.
+func TestMultiFloat64Combined(t *testing.T) {
+	m := NewMulti(10, Component{Label: "stat", Delta: 0.3}, Component{Label: "syst", Delta: 0.4})
+	tests := []struct {
+		name     string
+		p        Propagator
+		expected Float64
+	}{
+		{name: "worst case", p: WorstCase{}, expected: New(10, 0.7)},
+		{name: "quadrature", p: Quadrature{}, expected: New(10, 0.5)},
+	}
+	for _, test := range tests {
+		prev := SetPropagator(test.p)
+		actual := m.Float64()
+		SetPropagator(prev)
+		if !near(actual, test.expected) {
+			t.Errorf("%v: expected: %v, actual: %v", test.name, test.expected, actual)
+		}
+	}
+	if actual := m.Component("syst"); actual != 0.4 {
+		t.Errorf("expected syst: 0.4, actual: %v", actual)
+	}
+	if actual := m.Component("lumi"); actual != 0 {
+		t.Errorf("expected lumi: 0, actual: %v", actual)
+	}
+}
+
+func TestWithLabel(t *testing.T) {
+	t.Parallel()
+	a := New(20, 0.5).WithLabel("thermometer A")
+	b := New(25, 0.2).WithLabel("thermometer B")
+	d := b.Sub(a).Mul(NewMulti(2))
+	for i := 0; i < 100; i++ {
+		d = d.Add(NewMulti(1)).Sub(NewMulti(1))
+	}
+	tests := []struct {
+		name     string
+		actual   MultiFloat64
+		expected []Component
+	}{
+		{
+			name:     "input",
+			actual:   a,
+			expected: []Component{{Label: "thermometer A", Delta: 0.5}},
+		},
+		{
+			name:     "float32",
+			actual:   NewNumber[float32](1, 0.5).WithLabel("x"),
+			expected: []Component{{Label: "x", Delta: 0.5}},
+		},
+		{
+			name:   "result",
+			actual: d,
+			expected: []Component{
+				{Label: "thermometer A", Delta: 1},
+				{Label: "thermometer B", Delta: 0.4},
+			},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := test.actual.Components(); !cmp.Equal(actual, test.expected) {
+				t.Errorf("diff: %v", cmp.Diff(test.expected, actual))
+			}
+		})
+	}
+}
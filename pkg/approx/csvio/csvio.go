@@ -0,0 +1,166 @@
+// Package csvio reads and writes tables of approximate numbers in the CSV
+// format, in which measurement data often comes.
+//
+// A table can hold an approximate number in one column, in any form accepted
+// by approx.Parse, e.g. "4.2±0.3", or in a pair of columns, with the value
+// and the delta.  The tables are read and written through encoding/csv, so
+// that the CSV dialect, and any header rows, are up to the caller.
+//
+// Example, for a table with the header "t,x,dx":
+//
+//     r := csv.NewReader(file)
+//     if _, err := r.Read(); err != nil { // Skip the header.
+//         // ...
+//     }
+//     cols, err := csvio.Read(r, csvio.Combined(0), csvio.Pair(1, 2))
+//     // cols[0] are the times, cols[1] are the positions.
+package csvio
+
+import (
+	"encoding/csv"
+	"errors"
+	"fmt"
+	"io"
+	"strconv"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+// ErrLength is returned when writing columns of different lengths.
+var ErrLength = errors.New("columns of different lengths")
+
+// Column tells Read where to find the approximate numbers of one column of the
+// result.  Use Combined or Pair to create it.
+type Column struct {
+	// value is the index of the CSV column with the value, or with the whole
+	// approximate number if delta is negative.
+	value int
+	// delta is the index of the CSV column with the delta.
+	delta int
+}
+
+// Combined returns a Column read from the CSV column with index i, which holds
+// approximate numbers in any form accepted by approx.Parse.
+func Combined(i int) Column {
+	return Column{value: i, delta: -1}
+}
+
+// Pair returns a Column read from two CSV columns, with indices value and
+// delta, which hold the values and the deltas, respectively.  An empty delta
+// is zero.
+func Pair(value, delta int) Column {
+	return Column{value: value, delta: delta}
+}
+
+// Read reads all the remaining records of r, and returns the approximate
+// numbers of each of cols, in the order of cols.  Returns an error with the
+// line and the column of the first cell which could not be parsed.
+func Read(r *csv.Reader, cols ...Column) ([][]approx.Float64, error) {
+	result := make([][]approx.Float64, len(cols))
+	for {
+		record, err := r.Read()
+		if err == io.EOF {
+			return result, nil
+		}
+		if err != nil {
+			return nil, err
+		}
+		for i, c := range cols {
+			f, err := c.read(r, record)
+			if err != nil {
+				return nil, err
+			}
+			result[i] = append(result[i], f)
+		}
+	}
+}
+
+// read reads the approximate number of c from record, the last record read
+// from r.
+func (c Column) read(r *csv.Reader, record []string) (approx.Float64, error) {
+	cell := func(i int) (string, error) {
+		if i >= len(record) {
+			line, _ := r.FieldPos(0)
+			return "", fmt.Errorf("line %v: no column %v", line, i+1)
+		}
+		return record[i], nil
+	}
+	errorf := func(i int, err error) error {
+		line, col := r.FieldPos(i)
+		return fmt.Errorf("line %v, column %v: %w", line, col, err)
+	}
+	s, err := cell(c.value)
+	if err != nil {
+		return approx.Float64{}, err
+	}
+	if c.delta < 0 {
+		f, err := approx.Parse(s)
+		if err != nil {
+			return approx.Float64{}, errorf(c.value, err)
+		}
+		return f, nil
+	}
+	val, err := strconv.ParseFloat(s, 64)
+	if err != nil {
+		return approx.Float64{}, errorf(c.value, err)
+	}
+	s, err = cell(c.delta)
+	if err != nil {
+		return approx.Float64{}, err
+	}
+	var delta float64
+	if s != "" {
+		if delta, err = strconv.ParseFloat(s, 64); err != nil {
+			return approx.Float64{}, errorf(c.delta, err)
+		}
+	}
+	return approx.New(val, delta), nil
+}
+
+// Write writes cols to w as the columns of a table, one approximate number
+// per cell, with full precision, e.g. "4.2±0.3".  Round the numbers first to
+// drop insignificant digits, see approx.Round.  All cols must have the same
+// length.
+func Write(w *csv.Writer, cols ...[]approx.Float64) error {
+	return write(w, cols, func(f approx.Float64) []string {
+		text, _ := f.MarshalText()
+		return []string{string(text)}
+	})
+}
+
+// WritePairs writes cols to w as the columns of a table, each approximate
+// number in two cells, with the value and the delta.  All cols must have the
+// same length.
+func WritePairs(w *csv.Writer, cols ...[]approx.Float64) error {
+	return write(w, cols, func(f approx.Float64) []string {
+		return []string{
+			strconv.FormatFloat(f.Value(), 'g', -1, 64),
+			strconv.FormatFloat(f.Delta(), 'g', -1, 64),
+		}
+	})
+}
+
+// write writes cols to w, with the cells of each approximate number given by
+// cells, and flushes w.
+func write(w *csv.Writer, cols [][]approx.Float64, cells func(approx.Float64) []string) error {
+	n := 0
+	if len(cols) > 0 {
+		n = len(cols[0])
+	}
+	for _, c := range cols {
+		if len(c) != n {
+			return fmt.Errorf("could not write lengths %v and %v: %w", n, len(c), ErrLength)
+		}
+	}
+	for i := 0; i < n; i++ {
+		var record []string
+		for _, c := range cols {
+			record = append(record, cells(c[i])...)
+		}
+		if err := w.Write(record); err != nil {
+			return err
+		}
+	}
+	w.Flush()
+	return w.Error()
+}
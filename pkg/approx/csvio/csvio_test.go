@@ -0,0 +1,139 @@
+package csvio
+
+import (
+	"encoding/csv"
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/filmil/approx/pkg/approx"
+	"github.com/google/go-cmp/cmp"
+)
+
+var opts = []cmp.Option{cmp.AllowUnexported(approx.Float64{})}
+
+func TestRead(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		input    string
+		cols     []Column
+		expected [][]approx.Float64
+		err      string
+	}{
+		{
+			name:  "combined",
+			input: "1±0.1,x\n2+-0.2,y\n",
+			cols:  []Column{Combined(0)},
+			expected: [][]approx.Float64{
+				{approx.New(1, 0.1), approx.New(2, 0.2)},
+			},
+		},
+		{
+			name:  "pairs",
+			input: "1,10,0.5\n2,20,\n",
+			cols:  []Column{Combined(0), Pair(1, 2)},
+			expected: [][]approx.Float64{
+				{approx.New(1, 0), approx.New(2, 0)},
+				{approx.New(10, 0.5), approx.New(20, 0)},
+			},
+		},
+		{
+			name:     "empty",
+			input:    "",
+			cols:     []Column{Combined(0)},
+			expected: [][]approx.Float64{nil},
+		},
+		{
+			name:  "bad combined",
+			input: "1±0.1\n2±x\n",
+			cols:  []Column{Combined(0)},
+			err:   "line 2, column 1",
+		},
+		{
+			name:  "bad value",
+			input: "1,x,2\n",
+			cols:  []Column{Pair(1, 2)},
+			err:   "line 1, column 3",
+		},
+		{
+			name:  "bad delta",
+			input: "1,2,x\n",
+			cols:  []Column{Pair(1, 2)},
+			err:   "line 1, column 5",
+		},
+		{
+			name:  "missing column",
+			input: "1,2,3\n",
+			cols:  []Column{Pair(1, 3)},
+			err:   "line 1: no column 4",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			r := csv.NewReader(strings.NewReader(test.input))
+			r.FieldsPerRecord = -1
+			actual, err := Read(r, test.cols...)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, actual: %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestWrite(t *testing.T) {
+	t.Parallel()
+	cols := [][]approx.Float64{
+		{approx.New(1, 0.1), approx.New(2, 0)},
+		{approx.New(-10, 0.5), approx.New(1e-20, 2e-21)},
+	}
+	var b strings.Builder
+	if err := Write(csv.NewWriter(&b), cols...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "1±0.1,-10±0.5\n2±0,1e-20±2e-21\n"; b.String() != expected {
+		t.Errorf("expected: %q, actual: %q", expected, b.String())
+	}
+	actual, err := Read(csv.NewReader(strings.NewReader(b.String())), Combined(0), Combined(1))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(actual, cols, opts...) {
+		t.Errorf("round trip: expected: %v, actual: %v", cols, actual)
+	}
+
+	b.Reset()
+	if err := WritePairs(csv.NewWriter(&b), cols...); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if expected := "1,0.1,-10,0.5\n2,0,1e-20,2e-21\n"; b.String() != expected {
+		t.Errorf("expected: %q, actual: %q", expected, b.String())
+	}
+	actual, err = Read(csv.NewReader(strings.NewReader(b.String())), Pair(0, 1), Pair(2, 3))
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !cmp.Equal(actual, cols, opts...) {
+		t.Errorf("round trip: expected: %v, actual: %v", cols, actual)
+	}
+}
+
+func TestWriteLength(t *testing.T) {
+	t.Parallel()
+	var b strings.Builder
+	err := Write(csv.NewWriter(&b), []approx.Float64{approx.New(1, 0)}, nil)
+	if !errors.Is(err, ErrLength) {
+		t.Errorf("expected ErrLength, got: %v", err)
+	}
+}
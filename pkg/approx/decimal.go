@@ -0,0 +1,334 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+	"math/big"
+	"strconv"
+	"strings"
+)
+
+// Decimal is an approximate number whose value and delta are decimal
+// numbers.
+//
+// Values like 0.1±0.01 can not be represented exactly in binary floating
+// point.  Decimal stores them exactly, so they round-trip through ParseDecimal
+// and String unchanged, and sums, differences and products are exact.  This
+// matters e.g. when working with calibration certificates, which quote
+// their values in decimal.
+//
+// The uncertainty is propagated in the worst case manner, same as by
+// WorstCase.
+//
+// Example:
+//     a, _ := approx.ParseDecimal("0.1±0.01")
+//     b, _ := approx.ParseDecimal("0.2±0.02")
+//     a.Add(b)  // 0.3±0.03
+//     a.MulA(b) // 0.02±0.004
+type Decimal struct {
+	val, delta decimal
+}
+
+// decimal is the number coef*10^exp.
+type decimal struct {
+	coef *big.Int
+	exp  int
+}
+
+// ParseDecimal parses an approximate number from a string, in any of the
+// forms accepted by Parse, into a Decimal.  The numbers may be given in the
+// exponent notation, e.g. "1.5e-3±2e-4", with exponents of at most 10000 in
+// magnitude.
+//
+// The returned error is a *ParseError.
+func ParseDecimal(s string) (Decimal, error) {
+	return parseLiteral(s, literal.decimal)
+}
+
+// decimal converts l, scanned from s, to a Decimal.
+func (l literal) decimal(s string) (Decimal, error) {
+	val, err := parseDecimal(unspaced(l.val))
+	if err != nil {
+		return Decimal{}, l.valError(s)
+	}
+	if l.exact {
+		return Decimal{val: val, delta: decimal{coef: new(big.Int)}}, nil
+	}
+	delta, err := parseDecimal(unspaced(l.delta))
+	if err != nil {
+		return Decimal{}, l.deltaError(s)
+	}
+	half := decimal{coef: big.NewInt(5), exp: -1}
+	switch {
+	case l.interval:
+		min, max := val, delta
+		if max.cmp(min) < 0 {
+			return Decimal{}, parseError(s, 0, len(s), ErrBadValue)
+		}
+		return Decimal{val: min.add(max).mul(half), delta: max.add(min.neg()).mul(half)}, nil
+	case l.percent:
+		delta = val.mul(delta)
+		delta.exp -= 2
+	}
+	return Decimal{val: val, delta: delta.abs()}, nil
+}
+
+// DecimalFromFloat64 converts f into a Decimal.  The value and the delta are
+// converted via their shortest decimal representations, the ones printed by
+// f.String(), rather than via their exact binary values.
+func DecimalFromFloat64(f Float64) (Decimal, error) {
+	if math.IsInf(f.val, 0) || math.IsNaN(f.val) || math.IsInf(f.delta, 0) || math.IsNaN(f.delta) {
+		return Decimal{}, fmt.Errorf("could not convert to decimal: %v", f)
+	}
+	val, _ := parseDecimal(strconv.FormatFloat(f.val, 'g', -1, 64))
+	delta, _ := parseDecimal(strconv.FormatFloat(f.delta, 'g', -1, 64))
+	return Decimal{val: val, delta: delta}, nil
+}
+
+// maxDecimalExp is the largest magnitude of the exponent of a decimal
+// accepted by ParseDecimal.  It is far beyond the range of float64, while
+// keeping the numbers written out by String, and the powers of ten used to
+// align two numbers, to a sane size.
+const maxDecimalExp = 10000
+
+// parseDecimal parses a decimal number such as "-12.50" or "1.5e-3".
+func parseDecimal(s string) (decimal, error) {
+	mant, exp := s, 0
+	if i := strings.IndexAny(s, "eE"); i >= 0 {
+		e, err := strconv.Atoi(s[i+1:])
+		if err != nil || e < -maxDecimalExp || e > maxDecimalExp {
+			return decimal{}, fmt.Errorf("invalid exponent: %q", s)
+		}
+		mant, exp = s[:i], e
+	}
+	if i := strings.IndexByte(mant, '.'); i >= 0 {
+		exp -= len(mant) - i - 1
+		mant = mant[:i] + mant[i+1:]
+	}
+	digits := strings.TrimLeft(mant, "+-")
+	if len(digits) == 0 || len(mant)-len(digits) > 1 || strings.Trim(digits, "0123456789") != "" {
+		return decimal{}, fmt.Errorf("invalid decimal: %q", s)
+	}
+	if exp < -maxDecimalExp {
+		return decimal{}, fmt.Errorf("exponent out of range: %q", s)
+	}
+	coef, _ := new(big.Int).SetString(mant, 10)
+	return decimal{coef: coef, exp: exp}, nil
+}
+
+// String implements Stringer.  The numbers are printed in full, without an
+// exponent, with as many decimal places as they have.
+func (f Decimal) String() string {
+	return fmt.Sprintf("%v±%v", f.val, f.delta)
+}
+
+// Value returns the value at the center of f's interval, as a fraction.
+func (f Decimal) Value() *big.Rat {
+	return f.val.rat()
+}
+
+// Delta returns the delta around the interval, as a fraction.  delta is
+// nonnegative.
+func (f Decimal) Delta() *big.Rat {
+	return f.delta.rat()
+}
+
+// Min returns the minimal extreme value for f.
+func (f Decimal) Min() Decimal {
+	return Decimal{val: f.val.add(f.delta.neg()), delta: decimal{coef: new(big.Int)}}
+}
+
+// Max returns the maximal extreme value for f.
+func (f Decimal) Max() Decimal {
+	return Decimal{val: f.val.add(f.delta), delta: decimal{coef: new(big.Int)}}
+}
+
+// Float64 converts f to a Float64, with the delta widened to cover the
+// rounding of the value and the delta to float64.
+func (f Decimal) Float64() Float64 {
+	r := f.val.rat()
+	val, _ := r.Float64()
+	err := new(big.Rat).SetFloat64(val)
+	err.Abs(err.Sub(err, r))
+	delta, exact := err.Add(err, f.delta.rat()).Float64()
+	if !exact {
+		delta = math.Nextafter(delta, math.Inf(1))
+	}
+	return New(val, delta)
+}
+
+// Add computes f+g.  The result is exact.
+func (f Decimal) Add(g Decimal) Decimal {
+	return Decimal{val: f.val.add(g.val), delta: f.delta.add(g.delta)}
+}
+
+// Sub computes f-g.  The result is exact.
+func (f Decimal) Sub(g Decimal) Decimal {
+	return Decimal{val: f.val.add(g.val.neg()), delta: f.delta.add(g.delta)}
+}
+
+// MulA computes f*g.  The result is exact.
+//
+// Based on the first order Taylor expansion:
+//   d(f*g) = |g|*df + |f|*dg
+func (f Decimal) MulA(g Decimal) Decimal {
+	return Decimal{
+		val:   f.val.mul(g.val),
+		delta: g.val.abs().mul(f.delta).add(f.val.abs().mul(g.delta)),
+	}
+}
+
+// DivA computes f/g, rounded to the given number of decimal places.  The
+// rounding error is added to the delta.  Returns an error wrapping
+// ErrDivisorContainsZero if the interval of g contains zero.
+//
+// Based on the first order Taylor expansion:
+//   d(f/g) = (df + |f/g|*dg) / |g|
+func (f Decimal) DivA(g Decimal, places int) (Decimal, error) {
+	if g.Min().val.sign() <= 0 && g.Max().val.sign() >= 0 {
+		return Decimal{}, fmt.Errorf("could not divide %v by %v: %w", f, g, ErrDivisorContainsZero)
+	}
+	q, exact := f.val.quo(g.val, places, false)
+	delta, _ := f.delta.add(q.abs().mul(g.delta)).quo(g.val.abs(), places, true)
+	if !exact {
+		delta = delta.add(decimal{coef: big.NewInt(1), exp: -places})
+	}
+	return Decimal{val: q, delta: delta}, nil
+}
+
+// Lt returns true if f is definitely less than g.
+func (f Decimal) Lt(g Decimal) bool {
+	return f.Max().val.cmp(g.Min().val) < 0
+}
+
+// Le returns true if f is definitely either less than, or equal to g.
+func (f Decimal) Le(g Decimal) bool {
+	return f.Max().val.cmp(g.Min().val) <= 0
+}
+
+// Gt returns true if f is definitely greater than g.
+func (f Decimal) Gt(g Decimal) bool {
+	return g.Lt(f)
+}
+
+// Ge returns true if f is definitely either greater than, or equal to g.
+func (f Decimal) Ge(g Decimal) bool {
+	return g.Le(f)
+}
+
+// String implements Stringer.
+func (d decimal) String() string {
+	digits := new(big.Int).Abs(d.coef).String()
+	sign := ""
+	if d.coef.Sign() < 0 {
+		sign = "-"
+	}
+	if d.exp >= 0 {
+		if d.coef.Sign() == 0 {
+			return "0"
+		}
+		return sign + digits + strings.Repeat("0", d.exp)
+	}
+	places := -d.exp
+	if len(digits) <= places {
+		digits = strings.Repeat("0", places-len(digits)+1) + digits
+	}
+	i := len(digits) - places
+	return sign + digits[:i] + "." + digits[i:]
+}
+
+// rat returns d as a fraction.
+func (d decimal) rat() *big.Rat {
+	r := new(big.Rat).SetInt(d.coef)
+	p := new(big.Rat).SetInt(pow10(abs(d.exp)))
+	if d.exp < 0 {
+		return r.Quo(r, p)
+	}
+	return r.Mul(r, p)
+}
+
+// sign returns -1, 0 or 1, depending on the sign of d.
+func (d decimal) sign() int {
+	return d.coef.Sign()
+}
+
+// neg returns -d.
+func (d decimal) neg() decimal {
+	return decimal{coef: new(big.Int).Neg(d.coef), exp: d.exp}
+}
+
+// abs returns |d|.
+func (d decimal) abs() decimal {
+	return decimal{coef: new(big.Int).Abs(d.coef), exp: d.exp}
+}
+
+// add returns d+e, with the exponent of whichever has more decimal places.
+func (d decimal) add(e decimal) decimal {
+	x, y, exp := align(d, e)
+	return decimal{coef: x.Add(x, y), exp: exp}
+}
+
+// mul returns d*e.
+func (d decimal) mul(e decimal) decimal {
+	return decimal{coef: new(big.Int).Mul(d.coef, e.coef), exp: d.exp + e.exp}
+}
+
+// cmp compares d and e, returning -1, 0 or 1.
+func (d decimal) cmp(e decimal) int {
+	x, y, _ := align(d, e)
+	return x.Cmp(y)
+}
+
+// quo returns d/e rounded to the given number of decimal places, either to
+// the nearest, or away from zero if up is set.  Also returns whether the
+// quotient is exact.
+func (d decimal) quo(e decimal, places int, up bool) (decimal, bool) {
+	// d/e = (d.coef*10^shift / e.coef) * 10^-places
+	num, den := new(big.Int).Set(d.coef), new(big.Int).Set(e.coef)
+	if shift := d.exp - e.exp + places; shift >= 0 {
+		num.Mul(num, pow10(shift))
+	} else {
+		den.Mul(den, pow10(-shift))
+	}
+	q, r := new(big.Int).QuoRem(num, den, new(big.Int))
+	if r.Sign() == 0 {
+		return decimal{coef: q, exp: -places}, true
+	}
+	neg := num.Sign()*den.Sign() < 0
+	r.Abs(r).Lsh(r, 1)
+	if up || r.CmpAbs(den) >= 0 {
+		if neg {
+			q.Sub(q, big.NewInt(1))
+		} else {
+			q.Add(q, big.NewInt(1))
+		}
+	}
+	return decimal{coef: q, exp: -places}, false
+}
+
+// align returns the coefficients of d and e scaled to a common exponent,
+// which is also returned.
+func align(d, e decimal) (x, y *big.Int, exp int) {
+	x, y = new(big.Int).Set(d.coef), new(big.Int).Set(e.coef)
+	switch {
+	case d.exp > e.exp:
+		x.Mul(x, pow10(d.exp-e.exp))
+		return x, y, e.exp
+	case d.exp < e.exp:
+		y.Mul(y, pow10(e.exp-d.exp))
+	}
+	return x, y, d.exp
+}
+
+// pow10 returns 10^n for a nonnegative n.
+func pow10(n int) *big.Int {
+	return new(big.Int).Exp(big.NewInt(10), big.NewInt(int64(n)), nil)
+}
+
+// abs returns |n|.
+func abs(n int) int {
+	if n < 0 {
+		return -n
+	}
+	return n
+}
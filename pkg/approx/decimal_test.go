@@ -0,0 +1,136 @@
+package approx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func mustDecimal(f Decimal, err error) Decimal {
+	if err != nil {
+		panic(err)
+	}
+	return f
+}
+
+func TestParseDecimal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected string
+		err      bool
+	}{
+		{input: "0.1±0.01", expected: "0.1±0.01"},
+		{input: "0.10±0.01", expected: "0.10±0.01"},
+		{input: "-12.5 ± -0.5", expected: "-12.5±0.5"},
+		{input: "+3", expected: "3±0"},
+		{input: "1.5e-3±2e-4", expected: "0.0015±0.0002"},
+		{input: "1.5E3±2e1", expected: "1500±20"},
+		{input: ".5±5.", expected: "0.5±5"},
+		{input: "4.2+/-0.3", expected: "4.2±0.3"},
+		{input: "4.2(3)", expected: "4.2±0.3"},
+		{input: "1.234(5)e-3", expected: "0.001234±0.000005"},
+		{input: "4.2±5%", expected: "4.2±0.210"},
+		{input: "~4.2", expected: "4.2±0.05"},
+		{input: "[1, 2]", expected: "1.5±0.5"},
+		{input: "(1.5±0.25)e2", expected: "150±25"},
+		{input: "1e10000", expected: "1" + strings.Repeat("0", 10000) + "±0"},
+		{input: "1e10001", err: true},
+		{input: "1e9223372036854775807±1", err: true},
+		{input: "1e-9223372036854775808", err: true},
+		{input: "0.1e-10000", err: true},
+		{input: "[2, 1]", err: true},
+		{input: "", err: true},
+		{input: "1.2.3", err: true},
+		{input: "--1", err: true},
+		{input: "1e", err: true},
+		{input: "1±abc", err: true},
+		{input: "1±2±3", err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			actual, err := ParseDecimal(test.input)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if err == nil && actual.String() != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseDecimalError(t *testing.T) {
+	t.Parallel()
+	_, err := ParseDecimal("1e99999 ± 1")
+	var e *ParseError
+	if !errors.As(err, &e) || !errors.Is(err, ErrBadValue) {
+		t.Fatalf("expected a ParseError for the value, got: %v", err)
+	}
+	if e.Offset != 0 || e.Text != "1e99999" {
+		t.Errorf("expected 1e99999 at offset 0, got: %q at offset %v", e.Text, e.Offset)
+	}
+}
+
+func TestDecimalOps(t *testing.T) {
+	t.Parallel()
+	a := mustDecimal(ParseDecimal("0.1±0.01"))
+	b := mustDecimal(ParseDecimal("0.2±0.02"))
+	three := mustDecimal(ParseDecimal("3"))
+	tests := []struct {
+		name     string
+		actual   Decimal
+		expected string
+	}{
+		{name: "Add", actual: a.Add(b), expected: "0.3±0.03"},
+		{name: "Sub", actual: a.Sub(b), expected: "-0.1±0.03"},
+		{name: "MulA", actual: a.MulA(b), expected: "0.02±0.004"},
+		{name: "DivA", actual: mustDecimal(b.DivA(a, 3)), expected: "2.000±0.400"},
+		{name: "DivA inexact", actual: mustDecimal(a.DivA(three, 4)), expected: "0.0333±0.0035"},
+		{name: "DivA negative", actual: mustDecimal(a.Sub(b).DivA(three, 2)), expected: "-0.03±0.02"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if actual := test.actual.String(); actual != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDecimalDivByZero(t *testing.T) {
+	t.Parallel()
+	a := mustDecimal(ParseDecimal("1"))
+	b := mustDecimal(ParseDecimal("1±1"))
+	if _, err := a.DivA(b, 2); !errors.Is(err, ErrDivisorContainsZero) {
+		t.Errorf("expected error: %v, actual: %v", ErrDivisorContainsZero, err)
+	}
+}
+
+func TestDecimalConversion(t *testing.T) {
+	t.Parallel()
+	f := New(0.1, 0.01)
+	d := mustDecimal(DecimalFromFloat64(f))
+	if actual := d.String(); actual != "0.1±0.01" {
+		t.Errorf("expected: 0.1±0.01, actual: %v", actual)
+	}
+	back := d.Float64()
+	if back.Value() != 0.1 || back.Delta() <= 0.01 || back.Delta() > 0.01000000001 {
+		t.Errorf("expected: %v with the rounding errors added, actual: %v", f, back)
+	}
+	exact := mustDecimal(ParseDecimal("0.5±0.25")).Float64()
+	if !cmp.Equal(exact, New(0.5, 0.25), opts...) {
+		t.Errorf("expected: %v, actual: %v", New(0.5, 0.25), exact)
+	}
+	if _, err := DecimalFromFloat64(New(0, 0).RDivC(1)); err == nil {
+		t.Errorf("expected error for infinite value")
+	}
+	a, b := mustDecimal(ParseDecimal("1±0.5")), mustDecimal(ParseDecimal("2±0.5"))
+	if !a.Le(b) || a.Lt(b) || !b.Ge(a) || b.Gt(a) {
+		t.Errorf("comparisons of %v and %v are wrong", a, b)
+	}
+}
@@ -0,0 +1,130 @@
+package approx
+
+import (
+	"math"
+	"sync"
+	"unsafe"
+)
+
+// derivatives maps functions to their derivatives.  Functions are not
+// comparable in go, so they are keyed by the closures they point to, see
+// funcKey.
+var derivatives = struct {
+	sync.RWMutex
+	m map[unsafe.Pointer]derivativeOf
+}{m: map[unsafe.Pointer]derivativeOf{}}
+
+// derivativeOf is a registered derivative.  It keeps the function as well, so
+// that its closure is not freed and reused for another function.
+type derivativeOf struct {
+	f, df func(float64) float64
+}
+
+func init() {
+	RegisterDerivative(math.Exp, math.Exp)
+	RegisterDerivative(math.Log, func(x float64) float64 { return 1 / x })
+	RegisterDerivative(math.Expm1, math.Exp)
+	RegisterDerivative(math.Log10, func(x float64) float64 { return 1 / (x * math.Ln10) })
+	RegisterDerivative(math.Log2, func(x float64) float64 { return 1 / (x * math.Ln2) })
+	RegisterDerivative(math.Log1p, func(x float64) float64 { return 1 / (1 + x) })
+	RegisterDerivative(math.Sqrt, func(x float64) float64 { return 0.5 / math.Sqrt(x) })
+	RegisterDerivative(math.Cbrt, func(x float64) float64 {
+		c := math.Cbrt(x)
+		return 1 / (3 * c * c)
+	})
+	RegisterDerivative(math.Sin, math.Cos)
+	RegisterDerivative(math.Cos, func(x float64) float64 { return -math.Sin(x) })
+	RegisterDerivative(math.Asin, func(x float64) float64 { return 1 / math.Sqrt(1-x*x) })
+	RegisterDerivative(math.Acos, func(x float64) float64 { return -1 / math.Sqrt(1-x*x) })
+	RegisterDerivative(math.Atan, func(x float64) float64 { return 1 / (1 + x*x) })
+	RegisterDerivative(math.Sinh, math.Cosh)
+	RegisterDerivative(math.Cosh, math.Sinh)
+	RegisterDerivative(math.Tanh, func(x float64) float64 {
+		t := math.Tanh(x)
+		return 1 - t*t
+	})
+	RegisterDerivative(math.Tan, func(x float64) float64 {
+		c := math.Cos(x)
+		return 1 / (c * c)
+	})
+}
+
+// RegisterDerivative registers df as the derivative of f.  Apply uses the
+// registered derivative instead of a numeric approximation, which makes the
+// propagation exact to the first order.
+//
+// Derivatives of the common functions from the math package are registered
+// by default.  Registering a derivative for an already registered function
+// replaces the previous registration.
+//
+// Functions are identified by their closure, so closures created from the
+// same function literal are told apart.  Pass the registered function value
+// itself to Apply: a method value, such as x.M, and a function literal which
+// captures variables create a new closure each time they are evaluated.
+//
+// Example:
+//     cube := func(x float64) float64 { return x * x * x }
+//     approx.RegisterDerivative(cube, func(x float64) float64 { return 3 * x * x })
+//     approx.New(2, 0.1).ApplyAuto(cube) // 8±1.2
+func RegisterDerivative(f, df func(float64) float64) {
+	derivatives.Lock()
+	defer derivatives.Unlock()
+	derivatives.m[funcKey(f)] = derivativeOf{f: f, df: df}
+}
+
+// Derivative returns the derivative registered for f, if any.  See
+// RegisterDerivative.
+func Derivative(f func(float64) float64) (func(float64) float64, bool) {
+	derivatives.RLock()
+	defer derivatives.RUnlock()
+	d, ok := derivatives.m[funcKey(f)]
+	return d.df, ok
+}
+
+// funcKey returns the key of f in derivatives.  A function value points to
+// its closure, which is unique to a top-level function or to a function
+// literal without captured variables, and is allocated anew for every
+// evaluation of a function literal which captures variables.  Unlike the
+// code pointer of f, it tells such closures apart.
+func funcKey(f func(float64) float64) unsafe.Pointer {
+	return *(*unsafe.Pointer)(unsafe.Pointer(&f))
+}
+
+// derivative computes the derivative of fx at x.  The registered derivative
+// is used if there is one, otherwise the derivative is computed numerically
+// by Richardson extrapolation of central differences on the interval
+// [x-eps, x+eps].  If eps is not positive, it is chosen automatically.
+func derivative(fx func(float64) float64, x, eps float64) float64 {
+	if dfx, ok := Derivative(fx); ok {
+		return dfx(x)
+	}
+	if eps <= 0 {
+		eps = richardsonStep(x)
+	}
+	return richardson(fx, x, eps)
+}
+
+// richardson computes the derivative of fx at x by Richardson extrapolation
+// of the central differences with steps h and h/2.  The error of a central
+// difference with step h is:
+//   D(h) = f'(x) + c*h^2 + O(h^4)
+// so the h^2 terms cancel out in:
+//   f'(x) = (4*D(h/2) - D(h)) / 3 + O(h^4)
+func richardson(fx func(float64) float64, x, h float64) float64 {
+	d1 := (fx(x+h) - fx(x-h)) / (2 * h)
+	d2 := (fx(x+h/2) - fx(x-h/2)) / h
+	return (4*d2 - d1) / 3
+}
+
+// richardsonStep returns the step size to use for richardson around x.  Since
+// the truncation error is O(h^4), the step size which balances it against the
+// rounding error is larger than the one for a plain central difference, see
+// step.  Such a step is too large for functions with features at the scale of
+// a small x, such as 1/x, so it is scaled by |x| unless x is zero.
+func richardsonStep(x float64) float64 {
+	scale := math.Abs(x)
+	if scale == 0 {
+		scale = 1
+	}
+	return math.Pow(epsilon, 0.2) * scale
+}
@@ -0,0 +1,76 @@
+package approx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestRegisterDerivative(t *testing.T) {
+	t.Parallel()
+	cube := func(x float64) float64 { return x * x * x }
+	RegisterDerivative(cube, func(x float64) float64 { return 3 * x * x })
+	if _, ok := Derivative(cube); !ok {
+		t.Errorf("expected a derivative for cube")
+	}
+	// With the numeric derivative, eps this large would be way off.
+	expected := New(8, 1.2000000000000002)
+	if actual := New(2, 0.1).Apply(cube, 1); !cmp.Equal(actual, expected, opts...) {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+}
+
+// TestClosures checks that closures from the same function literal get
+// their own derivatives, which keying the registry by the code of the
+// functions would mix up.  The registered derivatives are off by a factor of
+// ten, to tell them from the numeric ones.
+func TestClosures(t *testing.T) {
+	t.Parallel()
+	var fns []func(float64) float64
+	for _, k := range []float64{2, 3} {
+		k := k
+		f := func(x float64) float64 { return k * x }
+		RegisterDerivative(f, func(float64) float64 { return 10 * k })
+		fns = append(fns, f)
+	}
+	for i, expected := range []Float64{New(2, 2), New(3, 3)} {
+		if actual := New(1, 0.1).ApplyAuto(fns[i]); !cmp.Equal(actual, expected, opts...) {
+			t.Errorf("closure %v: expected: %v, actual: %v", i, expected, actual)
+		}
+	}
+	unregistered := func(x float64) float64 { return fns[0](x) }
+	if _, ok := Derivative(unregistered); ok {
+		t.Errorf("unexpected derivative for an unregistered function")
+	}
+}
+
+func TestBuiltinDerivatives(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		f        func(float64) float64
+		x        float64
+		expected float64
+	}{
+		{name: "exp", f: math.Exp, x: 1, expected: math.E},
+		{name: "log", f: math.Log, x: 2, expected: 0.5},
+		{name: "sqrt", f: math.Sqrt, x: 4, expected: 0.25},
+		{name: "sin", f: math.Sin, x: 0, expected: 1},
+		{name: "cos", f: math.Cos, x: 0, expected: 0},
+		{name: "tan", f: math.Tan, x: 0, expected: 1},
+		{name: "lgamma", f: LgammaFunc, x: 1, expected: -0.5772156649015329},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			df, ok := Derivative(test.f)
+			if !ok {
+				t.Fatalf("no derivative registered")
+			}
+			if actual := df(test.x); math.Abs(actual-test.expected) > 1e-12 {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,167 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+)
+
+// Distribution is the kind of probability distribution that the uncertainty
+// of a Float64 follows.  It determines how the delta of a Float64 relates to
+// its standard uncertainty, see Float64.StdDev.
+//
+// The GUM distinguishes uncertainties obtained by statistical analysis of
+// series of observations (Type A, usually Gaussian), from uncertainties
+// obtained by other means (Type B), such as the resolution of an instrument
+// (Uniform), or a tolerance from a datasheet (Uniform or Triangular).
+type Distribution int
+
+const (
+	// Unspecified is the distribution of a Float64 whose distribution was
+	// never declared.  The delta is treated as a standard uncertainty where
+	// one is needed.  This is the default.
+	Unspecified Distribution = iota
+	// Gaussian is the normal distribution.  The delta is its standard
+	// deviation.
+	Gaussian
+	// Uniform is the uniform distribution over [Min(), Max()].  The delta is
+	// the half-width of the interval.
+	Uniform
+	// Triangular is the symmetric triangular distribution over
+	// [Min(), Max()], with the peak at Value().  The delta is the half-width
+	// of the interval.
+	Triangular
+)
+
+// Rectangular is another name for the Uniform distribution, as used in the
+// GUM.
+const Rectangular = Uniform
+
+// String implements Stringer.
+func (d Distribution) String() string {
+	switch d {
+	case Unspecified:
+		return "unspecified"
+	case Gaussian:
+		return "gaussian"
+	case Uniform:
+		return "uniform"
+	case Triangular:
+		return "triangular"
+	default:
+		return fmt.Sprintf("Distribution(%d)", int(d))
+	}
+}
+
+// Bounded returns true if d has a finite support, in which case the delta is
+// a hard bound on the deviation from the value.
+func (d Distribution) Bounded() bool {
+	return d == Uniform || d == Triangular
+}
+
+// divisor returns the number by which delta is divided to obtain the
+// standard uncertainty.
+func (d Distribution) divisor() float64 {
+	switch d {
+	case Uniform:
+		return math.Sqrt(3)
+	case Triangular:
+		return math.Sqrt(6)
+	default:
+		return 1
+	}
+}
+
+// Distribution returns the kind of distribution of f's uncertainty.
+func (f Number[T]) Distribution() Distribution {
+	return f.dist
+}
+
+// WithDistribution returns a copy of f whose uncertainty follows the
+// distribution d.
+//
+// Example:
+//     // A tolerance of ±0.5 from a datasheet.
+//     r := approx.New(100, 0.5).WithDistribution(approx.Uniform)
+//     r.StdDev() // 0.2886751345948129
+func (f Number[T]) WithDistribution(d Distribution) Number[T] {
+	f.dist = d
+	return f
+}
+
+// StdDev returns the standard uncertainty of f, which is its delta scaled
+// according to f's distribution.
+func (f Number[T]) StdDev() float64 {
+	return float64(f.delta) / f.dist.divisor()
+}
+
+// coverage is used by the comparisons of Gaussian numbers.
+var coverage = 2.0
+
+// SetCoverage makes k the coverage factor used by the comparisons, such as Lt
+// and Overlap, and returns the previously used coverage factor so that it can
+// be restored later.  The default is 2, for a coverage probability of about
+// 95%, see CoverageFactor.
+//
+// The comparisons treat a number as the interval of its possible values.  The
+// delta of a bounded distribution, or of an unspecified one, is the
+// half-width of that interval.  A Gaussian is unbounded, so the comparisons
+// use the interval of k standard deviations around its value instead.
+//
+// SetCoverage is meant to be called during program initialization.  It is not
+// safe to call it concurrently with comparisons.
+func SetCoverage(k float64) float64 {
+	prev := coverage
+	coverage = k
+	return prev
+}
+
+// CurrentCoverage returns the coverage factor used by the comparisons.
+func CurrentCoverage() float64 {
+	return coverage
+}
+
+// reach returns the half-width of the interval of f used by the comparisons,
+// see SetCoverage.
+func (f Number[T]) reach() float64 {
+	if f.dist == Gaussian {
+		return coverage * f.StdDev()
+	}
+	return float64(f.delta)
+}
+
+// low returns the lower end of the interval of f used by the comparisons.
+func (f Number[T]) low() float64 {
+	return float64(f.val) - f.reach()
+}
+
+// high returns the upper end of the interval of f used by the comparisons.
+func (f Number[T]) high() float64 {
+	return float64(f.val) + f.reach()
+}
+
+// combineDist returns the distribution of a result computed by linear
+// propagation from a and b.  A distribution survives if the other operand is
+// exact.  Otherwise, only Gaussians stay Gaussian, since e.g. a sum of two
+// uniform distributions is not uniform.
+func combineDist(a, b Float64) Distribution {
+	switch {
+	case a.delta == 0:
+		return b.dist
+	case b.delta == 0:
+		return a.dist
+	case a.dist == Gaussian && b.dist == Gaussian:
+		return Gaussian
+	default:
+		return Unspecified
+	}
+}
+
+// quadratureDist returns the distribution of a result computed by adding the
+// standard uncertainties of a and b in quadrature.  By the central limit
+// theorem, combining declared distributions yields a Gaussian.
+func quadratureDist(a, b Float64) Distribution {
+	if a.dist == Unspecified && b.dist == Unspecified {
+		return Unspecified
+	}
+	return Gaussian
+}
@@ -0,0 +1,128 @@
+package approx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestStdDev(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    Float64
+		expected float64
+	}{
+		{input: New(1, 2), expected: 2},
+		{input: New(1, 2).WithDistribution(Gaussian), expected: 2},
+		{input: New(1, math.Sqrt(3)).WithDistribution(Uniform), expected: 1},
+		{input: New(1, math.Sqrt(6)).WithDistribution(Triangular), expected: 1},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input.Distribution().String(), func(t *testing.T) {
+			if actual := test.input.StdDev(); actual != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDistributionPropagation(t *testing.T) {
+	t.Parallel()
+	uniform := New(1, math.Sqrt(3)).WithDistribution(Uniform)
+	gaussian := New(2, 1).WithDistribution(Gaussian)
+	tests := []struct {
+		name     string
+		actual   Float64
+		expected Float64
+	}{
+		{
+			name:     "worst case uniform+exact",
+			actual:   WorstCase{}.Add(uniform, New(1, 0)),
+			expected: New(2, math.Sqrt(3)).WithDistribution(Uniform),
+		},
+		{
+			name:     "worst case uniform+uniform",
+			actual:   WorstCase{}.Add(uniform, uniform),
+			expected: New(2, 2*math.Sqrt(3)),
+		},
+		{
+			name:     "worst case gaussian+gaussian",
+			actual:   WorstCase{}.Sub(gaussian, gaussian),
+			expected: New(0, 2).WithDistribution(Gaussian),
+		},
+		{
+			name:     "quadrature uniform+gaussian",
+			actual:   Quadrature{}.Add(uniform, gaussian),
+			expected: New(3, math.Sqrt2).WithDistribution(Gaussian),
+		},
+		{
+			name:     "quadrature uniform*exact",
+			actual:   Quadrature{}.Mul(uniform, New(2, 0)),
+			expected: New(2, 2*math.Sqrt(3)).WithDistribution(Uniform),
+		},
+		{
+			name:     "scale",
+			actual:   uniform.Mul(-1),
+			expected: New(-1, math.Sqrt(3)).WithDistribution(Uniform),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if !cmp.Equal(test.actual, test.expected, opts...) {
+				t.Errorf("expected: %v (%v), actual: %v (%v)",
+					test.expected, test.expected.Distribution(),
+					test.actual, test.actual.Distribution())
+			}
+		})
+	}
+}
+
+func TestDistributionComparisons(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name   string
+		a, b   Float64
+		lt, ov bool
+	}{
+		{name: "unspecified", a: New(0, 1), b: New(3, 1), lt: true},
+		{name: "uniform", a: New(0, 1).WithDistribution(Uniform), b: New(3, 1).WithDistribution(Uniform), lt: true},
+		{name: "gaussian", a: New(0, 1).WithDistribution(Gaussian), b: New(3, 1).WithDistribution(Gaussian), ov: true},
+		{name: "gaussian apart", a: New(0, 1).WithDistribution(Gaussian), b: New(5, 1).WithDistribution(Gaussian), lt: true},
+		{name: "mixed", a: New(0, 1).WithDistribution(Gaussian), b: New(2.5, 0.75).WithDistribution(Uniform), ov: true},
+		{name: "gaussian exact", a: New(0, 0).WithDistribution(Gaussian), b: New(0.5, 0), lt: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := test.a.Lt(test.b); actual != test.lt {
+				t.Errorf("%v.Lt(%v): expected: %v, actual: %v", test.a, test.b, test.lt, actual)
+			}
+			if actual := test.b.Gt(test.a); actual != test.lt {
+				t.Errorf("%v.Gt(%v): expected: %v, actual: %v", test.b, test.a, test.lt, actual)
+			}
+			if actual := Overlap(test.a, test.b); actual != test.ov {
+				t.Errorf("Overlap(%v, %v): expected: %v, actual: %v", test.a, test.b, test.ov, actual)
+			}
+		})
+	}
+}
+
+// Not parallel: changes the package-wide coverage factor.
+func TestSetCoverage(t *testing.T) {
+	prev := SetCoverage(1)
+	defer SetCoverage(prev)
+	if prev != 2 {
+		t.Errorf("expected 2 to be the default, got: %v", prev)
+	}
+	if actual := CurrentCoverage(); actual != 1 {
+		t.Errorf("expected: 1, actual: %v", actual)
+	}
+	a, b := New(0, 1).WithDistribution(Gaussian), New(3, 1).WithDistribution(Gaussian)
+	if !a.Lt(b) || Overlap(a, b) {
+		t.Errorf("expected %v to be less than %v with one standard deviation", a, b)
+	}
+}
@@ -0,0 +1,106 @@
+package approx
+
+import (
+	"bufio"
+	"fmt"
+	"io"
+	"strconv"
+)
+
+// WriteDOT writes the computation of t to w as a directed graph in the DOT
+// language of Graphviz, for debugging long chains of uncertain computations.
+// Each step of the History of t is a node, with edges from the operands to
+// the operations.
+//
+// The nodes are annotated with the results of the steps, and with their
+// contributions to the delta of t.  The contribution of a step is the delta
+// that t would have if all inputs other than the ones that the step was
+// computed from were exact.
+//
+// Example:
+//     l := approx.TraceNamed("length", approx.New(2, 0.01))
+//     w := approx.TraceNamed("width", approx.New(1, 0.02))
+//     l.Mul(w).WriteDOT(os.Stdout)
+//     // Then render with: dot -Tsvg
+func (t Traced) WriteDOT(w io.Writer) error {
+	bw := bufio.NewWriter(w)
+	fmt.Fprintln(bw, "digraph {")
+	root := t.node()
+	total := root.res.delta
+	index := make(map[*node]int)
+	var visit func(n *node) int
+	visit = func(n *node) int {
+		if i, ok := index[n]; ok {
+			return i
+		}
+		args := make([]int, len(n.args))
+		for j, a := range n.args {
+			args[j] = visit(a)
+		}
+		i := len(index)
+		index[n] = i
+		label := fmt.Sprintf("#%v", i)
+		switch {
+		case n.op != "":
+			label += " " + n.op
+		case n.name != "":
+			label += " " + n.name
+		}
+		label += fmt.Sprintf("\n%v", n.res)
+		if c := contribution(root, n); total != 0 {
+			label += fmt.Sprintf("\ncontribution %v (%.3g%%)", c, 100*c/total)
+		}
+		fmt.Fprintf(bw, "\tn%v [label=%v];\n", i, strconv.Quote(label))
+		for _, a := range args {
+			fmt.Fprintf(bw, "\tn%v -> n%v;\n", a, i)
+		}
+		return i
+	}
+	visit(root)
+	fmt.Fprintln(bw, "}")
+	return bw.Flush()
+}
+
+// contribution returns the delta of root, recomputed with all inputs exact
+// except for the ones that n was computed from.
+func contribution(root, n *node) float64 {
+	if n == root {
+		return root.res.delta
+	}
+	keep := make(map[*node]bool)
+	var mark func(n *node)
+	mark = func(n *node) {
+		if keep[n] {
+			return
+		}
+		keep[n] = true
+		for _, a := range n.args {
+			mark(a)
+		}
+	}
+	mark(n)
+	return replay(root, keep, make(map[*node]Float64)).delta
+}
+
+// replay recomputes n, with the inputs that are not in keep made exact.
+// Results are memoized in done.
+func replay(n *node, keep map[*node]bool, done map[*node]Float64) Float64 {
+	if r, ok := done[n]; ok {
+		return r
+	}
+	var r Float64
+	if n.eval == nil {
+		r = n.res
+		if !keep[n] {
+			r = New(r.val, 0)
+		}
+	} else {
+		args := make([]Float64, len(n.args))
+		for i, a := range n.args {
+			args[i] = replay(a, keep, done)
+		}
+		r = n.eval(args...)
+	}
+	done[n] = r
+	return r
+}
@@ -0,0 +1,67 @@
+package approx
+
+import (
+	"bytes"
+	"testing"
+)
+
+func TestWriteDOT(t *testing.T) {
+	t.Parallel()
+	l := TraceNamed("length", New(2, 0.01))
+	w := TraceNamed("width", New(1, 0.02))
+	tests := []struct {
+		name     string
+		actual   Traced
+		expected string
+	}{
+		{
+			name:   "product",
+			actual: l.Mul(w),
+			expected: "digraph {\n" +
+				"\tn0 [label=\"#0 length\\n2±0.01\\ncontribution 0.01 (20%)\"];\n" +
+				"\tn1 [label=\"#1 width\\n1±0.02\\ncontribution 0.04 (80%)\"];\n" +
+				"\tn2 [label=\"#2 mul\\n2±0.05\\ncontribution 0.05 (100%)\"];\n" +
+				"\tn0 -> n2;\n" +
+				"\tn1 -> n2;\n" +
+				"}\n",
+		},
+		{
+			name:   "exact",
+			actual: Trace(New(3, 0)).Scale(2),
+			expected: "digraph {\n" +
+				"\tn0 [label=\"#0\\n3±0\"];\n" +
+				"\tn1 [label=\"#1\\n2±0\"];\n" +
+				"\tn2 [label=\"#2 mul\\n6±0\"];\n" +
+				"\tn0 -> n2;\n" +
+				"\tn1 -> n2;\n" +
+				"}\n",
+		},
+		{
+			name:   "shared",
+			actual: l.Add(w).Mul(l),
+			expected: "digraph {\n" +
+				"\tn0 [label=\"#0 length\\n2±0.01\\ncontribution 0.05 (55.6%)\"];\n" +
+				"\tn1 [label=\"#1 width\\n1±0.02\\ncontribution 0.04 (44.4%)\"];\n" +
+				"\tn2 [label=\"#2 add\\n3±0.03\\ncontribution 0.09 (100%)\"];\n" +
+				"\tn0 -> n2;\n" +
+				"\tn1 -> n2;\n" +
+				"\tn3 [label=\"#3 mul\\n6±0.09\\ncontribution 0.09 (100%)\"];\n" +
+				"\tn2 -> n3;\n" +
+				"\tn0 -> n3;\n" +
+				"}\n",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var b bytes.Buffer
+			if err := test.actual.WriteDOT(&b); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual := b.String(); actual != test.expected {
+				t.Errorf("expected:\n%v\nactual:\n%v", test.expected, actual)
+			}
+		})
+	}
+}
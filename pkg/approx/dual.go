@@ -0,0 +1,160 @@
+package approx
+
+import "math"
+
+// Dual is a dual number for forward mode automatic differentiation.  It
+// carries a value, together with the exact partial derivatives of the value
+// with respect to a number of inputs.
+//
+// Functions written against Dual can be passed to ApplyDual, which computes
+// the result of the function with the uncertainty propagated by the exact
+// first derivatives, instead of numeric ones.
+//
+// Example:
+//     // f(x, y) = x*sin(y) + 2
+//     f := func(x []approx.Dual) approx.Dual {
+//         return x[0].Mul(x[1].Sin()).Add(approx.DualConst(2))
+//     }
+//     approx.ApplyDual(f, x, y)
+type Dual struct {
+	val float64
+	// grad holds the partial derivatives.  Missing entries are zero.
+	grad []float64
+}
+
+// DualConst returns a Dual for the constant c, whose derivatives are all
+// zero.
+func DualConst(c float64) Dual {
+	return Dual{val: c}
+}
+
+// DualVar returns a Dual for the i-th of n inputs, with the value val.
+func DualVar(val float64, i, n int) Dual {
+	grad := make([]float64, n)
+	grad[i] = 1
+	return Dual{val: val, grad: grad}
+}
+
+// Value returns the value of d.
+func (d Dual) Value() float64 {
+	return d.val
+}
+
+// Deriv returns the partial derivative of d with respect to the i-th input.
+func (d Dual) Deriv(i int) float64 {
+	if i < len(d.grad) {
+		return d.grad[i]
+	}
+	return 0
+}
+
+// Add computes d+e.
+func (d Dual) Add(e Dual) Dual {
+	return linear(d.val+e.val, 1, d, 1, e)
+}
+
+// Sub computes d-e.
+func (d Dual) Sub(e Dual) Dual {
+	return linear(d.val-e.val, 1, d, -1, e)
+}
+
+// Mul computes d*e.
+func (d Dual) Mul(e Dual) Dual {
+	return linear(d.val*e.val, e.val, d, d.val, e)
+}
+
+// Div computes d/e.
+func (d Dual) Div(e Dual) Dual {
+	q := d.val / e.val
+	return linear(q, 1/e.val, d, -q/e.val, e)
+}
+
+// Scale computes c*d.
+func (d Dual) Scale(c float64) Dual {
+	return d.chain(c*d.val, c)
+}
+
+// Neg computes -d.
+func (d Dual) Neg() Dual {
+	return d.Scale(-1)
+}
+
+// Pow computes d^p.
+func (d Dual) Pow(p float64) Dual {
+	return d.chain(math.Pow(d.val, p), p*math.Pow(d.val, p-1))
+}
+
+// Sqrt computes the square root of d.
+func (d Dual) Sqrt() Dual {
+	s := math.Sqrt(d.val)
+	return d.chain(s, 0.5/s)
+}
+
+// Exp computes e^d.
+func (d Dual) Exp() Dual {
+	e := math.Exp(d.val)
+	return d.chain(e, e)
+}
+
+// Log computes the natural logarithm of d.
+func (d Dual) Log() Dual {
+	return d.chain(math.Log(d.val), 1/d.val)
+}
+
+// Sin computes the sine of d.
+func (d Dual) Sin() Dual {
+	return d.chain(math.Sin(d.val), math.Cos(d.val))
+}
+
+// Cos computes the cosine of d.
+func (d Dual) Cos() Dual {
+	return d.chain(math.Cos(d.val), -math.Sin(d.val))
+}
+
+// Tan computes the tangent of d.
+func (d Dual) Tan() Dual {
+	c := math.Cos(d.val)
+	return d.chain(math.Tan(d.val), 1/(c*c))
+}
+
+// chain returns a Dual with value val, for a function of d whose derivative
+// at d is df.
+func (d Dual) chain(val, df float64) Dual {
+	grad := make([]float64, len(d.grad))
+	for i, g := range d.grad {
+		grad[i] = df * g
+	}
+	return Dual{val: val, grad: grad}
+}
+
+// linear returns a Dual with value val, whose derivatives are ca*d'+cb*e'.
+func linear(val, ca float64, d Dual, cb float64, e Dual) Dual {
+	n := len(d.grad)
+	if len(e.grad) > n {
+		n = len(e.grad)
+	}
+	grad := make([]float64, n)
+	for i := range grad {
+		grad[i] = ca*d.Deriv(i) + cb*e.Deriv(i)
+	}
+	return Dual{val: val, grad: grad}
+}
+
+// ApplyDual applies the function f to args, with the uncertainty propagated
+// through the exact derivatives of f obtained by automatic differentiation.
+//
+// The i-th Dual passed to f corresponds to args[i].  As in ApplyN, the
+// arguments are treated as independent, and their contributions are added up
+// in the worst case manner.
+func ApplyDual(f func([]Dual) Dual, args ...Float64) Float64 {
+	x := make([]Dual, len(args))
+	for i, a := range args {
+		x[i] = DualVar(a.val, i, len(args))
+	}
+	y := f(x)
+	r := New(y.val, 0)
+	for i, a := range args {
+		r = New(r.val, r.delta+math.Abs(y.Deriv(i)*a.delta)).WithDistribution(combineDist(r, a))
+	}
+	return r
+}
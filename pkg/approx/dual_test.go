@@ -0,0 +1,59 @@
+package approx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestDual(t *testing.T) {
+	t.Parallel()
+	x := DualVar(2, 0, 2)
+	y := DualVar(3, 1, 2)
+	tests := []struct {
+		name   string
+		actual Dual
+		val    float64
+		dx, dy float64
+	}{
+		{name: "x+y", actual: x.Add(y), val: 5, dx: 1, dy: 1},
+		{name: "x-y", actual: x.Sub(y), val: -1, dx: 1, dy: -1},
+		{name: "x*y", actual: x.Mul(y), val: 6, dx: 3, dy: 2},
+		{name: "x/y", actual: x.Div(y), val: 2.0 / 3, dx: 1.0 / 3, dy: -2.0 / 9},
+		{name: "x^3", actual: x.Pow(3), val: 8, dx: 12},
+		{name: "sqrt(y+1)", actual: y.Add(DualConst(1)).Sqrt(), val: 2, dy: 0.25},
+		{name: "exp(x)", actual: x.Exp(), val: math.Exp(2), dx: math.Exp(2)},
+		{name: "log(x)", actual: x.Log(), val: math.Log(2), dx: 0.5},
+		{name: "-2x", actual: x.Scale(2).Neg(), val: -4, dx: -2},
+		{name: "sin(x-x)", actual: x.Sub(x).Sin(), val: 0, dx: 0},
+		{name: "cos(x-x)", actual: x.Sub(x).Cos(), val: 1, dx: 0},
+		{name: "tan(x-x)", actual: x.Sub(x).Tan(), val: 0, dx: 0},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			a := test.actual
+			if a.Value() != test.val || a.Deriv(0) != test.dx || a.Deriv(1) != test.dy {
+				t.Errorf("expected: (%v, %v, %v), actual: (%v, %v, %v)",
+					test.val, test.dx, test.dy, a.Value(), a.Deriv(0), a.Deriv(1))
+			}
+		})
+	}
+}
+
+func TestApplyDual(t *testing.T) {
+	t.Parallel()
+	// x*x-2*x*y, where x and y are used several times.
+	f := func(x []Dual) Dual {
+		return x[0].Mul(x[0]).Sub(DualConst(2).Mul(x[0]).Mul(x[1]))
+	}
+	expected := New(-8, 4.2)
+	actual := ApplyDual(f, New(2, 0.1), New(3, 1))
+	if actual.Value() != expected.Value() || math.Abs(actual.Delta()-expected.Delta()) > 1e-12 {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+	if actual := ApplyDual(f, New(2, 0), New(3, 0)); !cmp.Equal(actual, New(-8, 0), opts...) {
+		t.Errorf("expected exact, actual: %v", actual)
+	}
+}
@@ -0,0 +1,253 @@
+package approx
+
+import (
+	"bytes"
+	binenc "encoding/binary"
+	"encoding/json"
+	"fmt"
+	"math"
+	"strconv"
+)
+
+// MarshalText implements encoding.TextMarshaler, so that approximate numbers
+// can be used in text based formats, e.g. as keys of JSON objects.  The value
+// and the delta are written with full precision, as by String with no
+// Rounding set, e.g. "4.2±0.3".
+func (f Number[T]) MarshalText() ([]byte, error) {
+	return f.AppendText(nil)
+}
+
+// AppendText implements encoding.TextAppender.  It appends the form written
+// by MarshalText to b, and allocates only if b needs to grow, so that hot
+// paths such as logging can format approximate numbers without allocating.
+func (f Number[T]) AppendText(b []byte) ([]byte, error) {
+	bits := 64
+	if float64(T(1+epsilon)) == 1 {
+		bits = 32
+	}
+	b = strconv.AppendFloat(b, float64(f.val), 'g', -1, bits)
+	b = append(b, "±"...)
+	return strconv.AppendFloat(b, float64(f.delta), 'g', -1, bits), nil
+}
+
+// UnmarshalText implements encoding.TextUnmarshaler.  It accepts any form
+// accepted by Parse.  The text written by MarshalText is read back exactly
+// into a Float64.  A Float32 may get its delta widened by the rounding of
+// the value to float32, see NewNumber.
+func (f *Number[T]) UnmarshalText(text []byte) error {
+	v, err := Parse(string(text))
+	if err != nil {
+		return fmt.Errorf("could not unmarshal approximate number: %w", err)
+	}
+	*f = Convert[T](v)
+	return nil
+}
+
+// text formats f as "v±d" with full precision, regardless of the current
+// Rounding.
+func (f Number[T]) text() string {
+	b, _ := f.AppendText(nil)
+	return string(b)
+}
+
+// JSONFormat selects the form in which MarshalJSON writes approximate
+// numbers.
+type JSONFormat int
+
+const (
+	// JSONString writes approximate numbers as strings, e.g. "4.2±0.3".
+	JSONString JSONFormat = iota
+	// JSONObject writes approximate numbers as objects, e.g.
+	// {"value":4.2,"delta":0.3}.
+	JSONObject
+)
+
+// jsonFormat is used by MarshalJSON.
+var jsonFormat = JSONString
+
+// SetJSONFormat makes format the JSONFormat used by MarshalJSON, and returns
+// the previously used JSONFormat so that it can be restored later.  The
+// default is JSONString.
+//
+// SetJSONFormat is meant to be called during program initialization.  It is
+// not safe to call it concurrently with marshaling.
+func SetJSONFormat(format JSONFormat) JSONFormat {
+	prev := jsonFormat
+	jsonFormat = format
+	return prev
+}
+
+// jsonObject is the object form of an approximate number in JSON.
+type jsonObject[T Float] struct {
+	Value *T `json:"value"`
+	Delta T  `json:"delta"`
+}
+
+// MarshalJSON implements json.Marshaler.  The form of the output is selected
+// by SetJSONFormat.  In the object form, numbers which are not finite can not
+// be marshaled.
+func (f Number[T]) MarshalJSON() ([]byte, error) {
+	if jsonFormat == JSONObject {
+		return json.Marshal(jsonObject[T]{Value: &f.val, Delta: f.delta})
+	}
+	return json.Marshal(f.text())
+}
+
+// UnmarshalJSON implements json.Unmarshaler.  It accepts either form written
+// by MarshalJSON, as well as a bare number, which is exact.  A string may be
+// in any form accepted by Parse, and the delta may be omitted from an object.
+//
+// Example:
+//     "4.2±0.3"                    // 4.2±0.3
+//     {"value": 4.2, "delta": 0.3} // 4.2±0.3
+//     4.2                          // 4.2±0
+func (f *Number[T]) UnmarshalJSON(data []byte) error {
+	data = bytes.TrimSpace(data)
+	switch {
+	case bytes.Equal(data, []byte("null")):
+		return nil
+	case len(data) > 0 && data[0] == '"':
+		var s string
+		if err := json.Unmarshal(data, &s); err != nil {
+			return fmt.Errorf("could not unmarshal approximate number: %w", err)
+		}
+		return f.UnmarshalText([]byte(s))
+	case len(data) > 0 && data[0] == '{':
+		var o jsonObject[float64]
+		if err := json.Unmarshal(data, &o); err != nil {
+			return fmt.Errorf("could not unmarshal approximate number: %w", err)
+		}
+		if o.Value == nil {
+			return fmt.Errorf("could not unmarshal approximate number %s: missing value: %w", data, ErrSyntax)
+		}
+		*f = Convert[T](New(*o.Value, o.Delta))
+		return nil
+	}
+	var v float64
+	if err := json.Unmarshal(data, &v); err != nil {
+		return fmt.Errorf("could not unmarshal approximate number: %w", err)
+	}
+	*f = Convert[T](New(v, 0))
+	return nil
+}
+
+// MarshalYAML implements the Marshaler interface of gopkg.in/yaml.v2 and
+// gopkg.in/yaml.v3, without this package depending on either.  Approximate
+// numbers are written as strings, as by MarshalText.
+func (f Number[T]) MarshalYAML() (interface{}, error) {
+	return f.text(), nil
+}
+
+// UnmarshalYAML implements the obsolete Unmarshaler interface of
+// gopkg.in/yaml.v2, which gopkg.in/yaml.v3 still honors, without this
+// package depending on either.  An approximate number is given as either:
+//
+//   - a string in any form accepted by Parse,
+//   - a number, which is exact, or
+//   - a mapping with the number value, and the optional number delta.
+//
+// Example, a table of instrument tolerances decoded into a
+// map[string]approx.Float64:
+//
+//     voltmeter: 12.02±0.05
+//     ammeter: "[0.98, 1.02]"
+//     thermometer: {value: 21.5, delta: 0.5}
+//     reference: 5
+func (f *Number[T]) UnmarshalYAML(unmarshal func(interface{}) error) error {
+	var v interface{}
+	if err := unmarshal(&v); err != nil {
+		return fmt.Errorf("could not unmarshal approximate number: %w", err)
+	}
+	switch v := v.(type) {
+	case string:
+		return f.UnmarshalText([]byte(v))
+	case map[string]interface{}:
+		return f.unmarshalMap(func(key string) (interface{}, bool) {
+			x, ok := v[key]
+			return x, ok
+		})
+	case map[interface{}]interface{}:
+		return f.unmarshalMap(func(key string) (interface{}, bool) {
+			x, ok := v[key]
+			return x, ok
+		})
+	}
+	if val, ok := yamlNumber(v); ok {
+		*f = Convert[T](New(val, 0))
+		return nil
+	}
+	return fmt.Errorf("could not unmarshal approximate number from %T: %w", v, ErrSyntax)
+}
+
+// unmarshalMap unmarshals f from a YAML mapping, with the entries given by
+// get.
+func (f *Number[T]) unmarshalMap(get func(key string) (interface{}, bool)) error {
+	x, ok := get("value")
+	if !ok {
+		return fmt.Errorf("could not unmarshal approximate number: missing value: %w", ErrSyntax)
+	}
+	val, ok := yamlNumber(x)
+	if !ok {
+		return fmt.Errorf("could not unmarshal approximate number: value %v is not a number: %w", x, ErrSyntax)
+	}
+	var delta float64
+	if x, ok := get("delta"); ok {
+		if delta, ok = yamlNumber(x); !ok {
+			return fmt.Errorf("could not unmarshal approximate number: delta %v is not a number: %w", x, ErrSyntax)
+		}
+	}
+	*f = Convert[T](New(val, delta))
+	return nil
+}
+
+// yamlNumber converts a number decoded from YAML to float64.
+func yamlNumber(x interface{}) (float64, bool) {
+	switch x := x.(type) {
+	case int:
+		return float64(x), true
+	case int64:
+		return float64(x), true
+	case uint64:
+		return float64(x), true
+	case float64:
+		return x, true
+	}
+	return 0, false
+}
+
+// binarySize is the size of the binary form of an approximate number.
+const binarySize = 16
+
+// MarshalBinary implements encoding.BinaryMarshaler.  The binary form takes
+// 16 bytes: the value and then the delta, each as an IEEE 754 float64 in the
+// big endian byte order.  Float32 numbers use the same form.  Like the text
+// form, the binary form does not include the Distribution.
+func (f Number[T]) MarshalBinary() ([]byte, error) {
+	data := make([]byte, binarySize)
+	binenc.BigEndian.PutUint64(data, math.Float64bits(float64(f.val)))
+	binenc.BigEndian.PutUint64(data[8:], math.Float64bits(float64(f.delta)))
+	return data, nil
+}
+
+// UnmarshalBinary implements encoding.BinaryUnmarshaler, reading the form
+// written by MarshalBinary.
+func (f *Number[T]) UnmarshalBinary(data []byte) error {
+	if len(data) != binarySize {
+		return fmt.Errorf("could not unmarshal approximate number: expected %v bytes, got: %v: %w", binarySize, len(data), ErrSyntax)
+	}
+	val := math.Float64frombits(binenc.BigEndian.Uint64(data))
+	delta := math.Float64frombits(binenc.BigEndian.Uint64(data[8:]))
+	*f = Convert[T](New(val, delta))
+	return nil
+}
+
+// GobEncode implements gob.GobEncoder, with the form written by
+// MarshalBinary.
+func (f Number[T]) GobEncode() ([]byte, error) {
+	return f.MarshalBinary()
+}
+
+// GobDecode implements gob.GobDecoder.
+func (f *Number[T]) GobDecode(data []byte) error {
+	return f.UnmarshalBinary(data)
+}
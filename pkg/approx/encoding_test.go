@@ -0,0 +1,350 @@
+package approx
+
+import (
+	"bytes"
+	"encoding/gob"
+	"encoding/json"
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestMarshalText(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f        Float64
+		expected string
+	}{
+		{f: New(4.2, 0.3), expected: "4.2±0.3"},
+		{f: New(100, 1.9999999999988916), expected: "100±1.9999999999988916"},
+		{f: New(-1e-20, 0), expected: "-1e-20±0"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			text, err := test.f.MarshalText()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(text) != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, text)
+			}
+			var actual Float64
+			if err := actual.UnmarshalText(text); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if actual.String() != test.f.String() {
+				t.Errorf("round trip: expected: %v, actual: %v", test.f, actual)
+			}
+		})
+	}
+}
+
+func TestAppendText(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f        interface{ AppendText([]byte) ([]byte, error) }
+		expected string
+	}{
+		{f: New(4.2, 0.3), expected: "x=4.2±0.3"},
+		{f: New(1e21, 1e-7), expected: "x=1e+21±1e-07"},
+		{f: New(math.Inf(-1), math.NaN()), expected: "x=-Inf±NaN"},
+		{f: NewNumber[float32](0.1, 0.01), expected: "x=0.1±0.010000002"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			actual, err := test.f.AppendText([]byte("x="))
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if string(actual) != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, actual)
+			}
+			if s := "x=" + fmt.Sprint(test.f); s != test.expected {
+				t.Errorf("expected the same as String: %q, actual: %q", s, actual)
+			}
+		})
+	}
+}
+
+func TestAppendTextAllocs(t *testing.T) {
+	f := New(1.2345678901234567, 0.0012345678901234567)
+	b := make([]byte, 0, 64)
+	allocs := testing.AllocsPerRun(100, func() {
+		b, _ = f.AppendText(b[:0])
+	})
+	if allocs != 0 {
+		t.Errorf("expected no allocations, got: %v", allocs)
+	}
+}
+
+func BenchmarkAppendText(b *testing.B) {
+	f := New(1.2345678901234567, 0.0012345678901234567)
+	buf := make([]byte, 0, 64)
+	for i := 0; i < b.N; i++ {
+		buf, _ = f.AppendText(buf[:0])
+	}
+}
+
+func TestUnmarshalText(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		text     string
+		expected Float64
+		err      bool
+	}{
+		{text: "4.2±0.3", expected: New(4.2, 0.3)},
+		{text: "4.2+/-0.3", expected: New(4.2, 0.3)},
+		{text: "[49.5, 50.5]", expected: New(50, 0.5)},
+		{text: "1.2345(12)", expected: New(1.2345, 0.0012)},
+		{text: "7", expected: New(7, 0)},
+		{text: "4.2±", err: true},
+		{text: "", err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.text, func(t *testing.T) {
+			t.Parallel()
+			var actual Float64
+			err := actual.UnmarshalText([]byte(test.text))
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !test.err && !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTextMapKeys(t *testing.T) {
+	t.Parallel()
+	expected := map[Float64]string{New(4.2, 0.3): "a", New(-1, 0): "b"}
+	data, err := json.Marshal(expected)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := string(data); s != `{"-1±0":"b","4.2±0.3":"a"}` {
+		t.Errorf("unexpected JSON: %v", s)
+	}
+	var actual map[Float64]string
+	if err := json.Unmarshal(data, &actual); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for k, v := range expected {
+		if actual[k] != v {
+			t.Errorf("key %v: expected: %q, actual: %q", k, v, actual[k])
+		}
+	}
+}
+
+func TestTextFloat32(t *testing.T) {
+	t.Parallel()
+	f := NewNumber[float32](0.1, 0.01)
+	text, err := f.MarshalText()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := string(text); s != "0.1±0.010000002" {
+		t.Errorf("unexpected text: %v", s)
+	}
+	var actual Float32
+	if err := actual.UnmarshalText(text); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual.Value() != f.Value() || actual.Delta() < f.Delta() {
+		t.Errorf("expected: %v, or wider, actual: %v", f, actual)
+	}
+}
+
+func TestMarshalJSON(t *testing.T) {
+	t.Parallel()
+	data, err := json.Marshal(struct {
+		A Float64  `json:"a"`
+		B *Float64 `json:"b"`
+	}{A: New(4.2, 0.3)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := string(data); s != `{"a":"4.2±0.3","b":null}` {
+		t.Errorf("unexpected JSON: %v", s)
+	}
+}
+
+// Not parallel: changes the package-wide JSON format.
+func TestSetJSONFormat(t *testing.T) {
+	prev := SetJSONFormat(JSONObject)
+	defer SetJSONFormat(prev)
+	if prev != JSONString {
+		t.Errorf("expected JSONString to be the default, got: %v", prev)
+	}
+	data, err := json.Marshal([]interface{}{New(4.2, 0.3), NewNumber[float32](0.5, 0)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if s := string(data); s != `[{"value":4.2,"delta":0.3},{"value":0.5,"delta":0}]` {
+		t.Errorf("unexpected JSON: %v", s)
+	}
+	if _, err := json.Marshal(New(math.Inf(1), 0)); err == nil {
+		t.Errorf("expected error for +Inf")
+	}
+}
+
+func TestUnmarshalJSON(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		data     string
+		expected Float64
+		err      error
+	}{
+		{data: `"4.2±0.3"`, expected: New(4.2, 0.3)},
+		{data: `"[49.5, 50.5]"`, expected: New(50, 0.5)},
+		{data: `{"value": 4.2, "delta": 0.3}`, expected: New(4.2, 0.3)},
+		{data: `{"delta": 0.3, "value": -4.2}`, expected: New(-4.2, 0.3)},
+		{data: `{"value": 7}`, expected: New(7, 0)},
+		{data: ` 4.2 `, expected: New(4.2, 0)},
+		{data: `-1e3`, expected: New(-1000, 0)},
+		{data: `null`, expected: New(1, 1)},
+		{data: `{"delta": 0.3}`, err: ErrSyntax},
+		{data: `"4.2±"`, err: errAny},
+		{data: `{"value": "4.2"}`, err: errAny},
+		{data: `true`, err: errAny},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.data, func(t *testing.T) {
+			t.Parallel()
+			actual := New(1, 1)
+			err := json.Unmarshal([]byte(test.data), &actual)
+			if test.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !near(actual, test.expected) {
+					t.Errorf("expected: %v, actual: %v", test.expected, actual)
+				}
+				return
+			}
+			if err == nil || test.err != errAny && !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v, actual: %v", test.err, err)
+			}
+		})
+	}
+}
+
+// errAny stands for any error in tests.
+var errAny = errors.New("any error")
+
+func TestMarshalYAML(t *testing.T) {
+	t.Parallel()
+	actual, err := New(4.2, 0.3).MarshalYAML()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual != "4.2±0.3" {
+		t.Errorf("expected: %q, actual: %q", "4.2±0.3", actual)
+	}
+}
+
+func TestUnmarshalYAML(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		node     interface{}
+		expected Float64
+		err      error
+	}{
+		{name: "string", node: "12.02±0.05", expected: New(12.02, 0.05)},
+		{name: "interval", node: "[0.98, 1.02]", expected: New(1, 0.02)},
+		{name: "int", node: 5, expected: New(5, 0)},
+		{name: "int64", node: int64(-5), expected: New(-5, 0)},
+		{name: "uint64", node: uint64(5), expected: New(5, 0)},
+		{name: "float", node: 21.5, expected: New(21.5, 0)},
+		{name: "v2 mapping", node: map[interface{}]interface{}{"value": 21.5, "delta": 0.5}, expected: New(21.5, 0.5)},
+		{name: "v3 mapping", node: map[string]interface{}{"value": 21.5, "delta": 1}, expected: New(21.5, 1)},
+		{name: "no delta", node: map[string]interface{}{"value": 21.5}, expected: New(21.5, 0)},
+		{name: "no value", node: map[string]interface{}{"delta": 0.5}, err: ErrSyntax},
+		{name: "bad value", node: map[string]interface{}{"value": "x"}, err: ErrSyntax},
+		{name: "bad delta", node: map[string]interface{}{"value": 1, "delta": true}, err: ErrSyntax},
+		{name: "sequence", node: []interface{}{1, 2}, err: ErrSyntax},
+		{name: "bad string", node: "4.2±", err: errAny},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			var actual Float64
+			err := actual.UnmarshalYAML(func(v interface{}) error {
+				*v.(*interface{}) = test.node
+				return nil
+			})
+			if test.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !near(actual, test.expected) {
+					t.Errorf("expected: %v, actual: %v", test.expected, actual)
+				}
+				return
+			}
+			if err == nil || test.err != errAny && !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v, actual: %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestMarshalBinary(t *testing.T) {
+	t.Parallel()
+	data, err := New(1, 0.5).MarshalBinary()
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	expected := []byte{0x3f, 0xf0, 0, 0, 0, 0, 0, 0, 0x3f, 0xe0, 0, 0, 0, 0, 0, 0}
+	if !bytes.Equal(data, expected) {
+		t.Errorf("expected: %x, actual: %x", expected, data)
+	}
+	tests := []Float64{New(4.2, 0.3), New(-1e-300, 0), New(math.Inf(1), 0), New(100, 1.9999999999988916)}
+	for _, f := range tests {
+		data, err := f.MarshalBinary()
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		var actual Float64
+		if err := actual.UnmarshalBinary(data); err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual != f {
+			t.Errorf("round trip: expected: %v, actual: %v", f, actual)
+		}
+	}
+	var f Float32
+	if err := f.UnmarshalBinary(data[:15]); !errors.Is(err, ErrSyntax) {
+		t.Errorf("expected ErrSyntax, got: %v", err)
+	}
+}
+
+func TestGob(t *testing.T) {
+	t.Parallel()
+	type record struct {
+		Name string
+		X    Float64
+		Y    Float32
+	}
+	expected := record{Name: "r", X: New(4.2, 0.3), Y: NewNumber[float32](0.5, 0.25)}
+	var buf bytes.Buffer
+	if err := gob.NewEncoder(&buf).Encode(expected); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	var actual record
+	if err := gob.NewDecoder(&buf).Decode(&actual); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual != expected {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+}
@@ -0,0 +1,332 @@
+package approx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// ErrUndefined is returned when an expression refers to an undefined variable
+// or function.
+var ErrUndefined = errors.New("undefined name")
+
+// Eval evaluates the arithmetic expression expr, with the values of its
+// variables given in vars, propagating the uncertainties of the variables
+// into the result.  This allows formulas to come from configuration files,
+// rather than be written in code.
+//
+// The expression can use numbers, which are exact unless given with an
+// uncertainty, e.g. 4.2±0.3, the variables, the operators +, -, *, / and ^
+// (power) with the usual precedence, parentheses, and the functions of this
+// package by their lowercase names, e.g. "sqrt(x)" or "atan2(y, x)".  The
+// constants pi and e are predefined, unless vars defines them.
+//
+// Every occurrence of a variable is treated as an independent measurement,
+// same as when computing with Float64 directly.  So write "x^2" rather than
+// "x*x", which accounts for both factors being the same measurement.
+//
+// Example:
+//     p, err := approx.Eval("2*(w+l)", map[string]approx.Float64{
+//         "w": approx.New(3, 0.1),
+//         "l": approx.New(4, 0.2),
+//     })
+//     // p is 14±0.6
+func Eval(expr string, vars map[string]Float64) (Float64, error) {
+	p := &parser{s: expr, vars: vars}
+	r, err := p.expr()
+	if err == nil && p.peek() != 0 {
+		err = p.errorf("unexpected %q", p.next())
+	}
+	if err != nil {
+		return Float64{}, fmt.Errorf("could not evaluate %q: %w", expr, err)
+	}
+	return r, nil
+}
+
+// evalFuncs are the functions that can be called from Eval, by name.
+var evalFuncs = map[string]func(x []Float64) (Float64, error){
+	"abs":    unary(Abs),
+	"acos":   unaryErr(Acos),
+	"asin":   unaryErr(Asin),
+	"atan":   unary(Atan),
+	"atan2":  binary(Atan2),
+	"cbrt":   unary(Cbrt),
+	"cos":    unary(Cos),
+	"cosh":   unary(Cosh),
+	"erf":    unary(Erf),
+	"erfc":   unary(Erfc),
+	"exp":    unary(Exp),
+	"expm1":  unary(Expm1),
+	"gamma":  unaryErr(Gamma),
+	"hypot":  binary(Hypot),
+	"lgamma": unaryErr(Lgamma),
+	"log":    unaryErr(Log),
+	"log10":  unaryErr(Log10),
+	"log1p":  unaryErr(Log1p),
+	"log2":   unaryErr(Log2),
+	"max":    binary(Max),
+	"min":    binary(Min),
+	"sin":    unary(Sin),
+	"sinh":   unary(Sinh),
+	"sqrt":   unaryErr(Sqrt),
+	"tan":    unary(Tan),
+	"tanh":   unary(Tanh),
+}
+
+// unary adapts a function of one argument to evalFuncs.
+func unary(f func(Float64) Float64) func([]Float64) (Float64, error) {
+	return unaryErr(func(x Float64) (Float64, error) { return f(x), nil })
+}
+
+// unaryErr adapts a function of one argument, which may fail, to evalFuncs.
+func unaryErr(f func(Float64) (Float64, error)) func([]Float64) (Float64, error) {
+	return func(x []Float64) (Float64, error) {
+		if len(x) != 1 {
+			return Float64{}, fmt.Errorf("expected 1 argument, got: %v: %w", len(x), ErrSyntax)
+		}
+		return f(x[0])
+	}
+}
+
+// binary adapts a function of two arguments to evalFuncs.
+func binary(f func(Float64, Float64) Float64) func([]Float64) (Float64, error) {
+	return func(x []Float64) (Float64, error) {
+		if len(x) != 2 {
+			return Float64{}, fmt.Errorf("expected 2 arguments, got: %v: %w", len(x), ErrSyntax)
+		}
+		return f(x[0], x[1]), nil
+	}
+}
+
+// parser is a recursive descent parser and evaluator for the grammar:
+//   expr    = term {("+" | "-") term}
+//   term    = unary {("*" | "/") unary}
+//   unary   = ("-" | "+") unary | power
+//   power   = primary ["^" unary]
+//   primary = number ["±" number] | name | name "(" expr {"," expr} ")" |
+//             "(" expr ")"
+type parser struct {
+	s    string
+	pos  int
+	vars map[string]Float64
+}
+
+// errorf returns a syntax error at the current position.
+func (p *parser) errorf(format string, args ...interface{}) error {
+	return fmt.Errorf("at position %v: %v: %w", p.pos, fmt.Sprintf(format, args...), ErrSyntax)
+}
+
+// peek skips spaces, and returns the next byte of input, or 0 at its end.
+func (p *parser) peek() byte {
+	for p.pos < len(p.s) && unicode.IsSpace(rune(p.s[p.pos])) {
+		p.pos++
+	}
+	if p.pos == len(p.s) {
+		return 0
+	}
+	return p.s[p.pos]
+}
+
+// accept consumes the next byte of input if it is c.
+func (p *parser) accept(c byte) bool {
+	if p.peek() == c {
+		p.pos++
+		return true
+	}
+	return false
+}
+
+func (p *parser) expr() (Float64, error) {
+	r, err := p.term()
+	for err == nil {
+		var t Float64
+		switch {
+		case p.accept('+'):
+			if t, err = p.term(); err == nil {
+				r = Add(r, t)
+			}
+		case p.accept('-'):
+			if t, err = p.term(); err == nil {
+				r = Sub(r, t)
+			}
+		default:
+			return r, nil
+		}
+	}
+	return Float64{}, err
+}
+
+func (p *parser) term() (Float64, error) {
+	r, err := p.unary()
+	for err == nil {
+		var t Float64
+		switch {
+		case p.accept('*'):
+			if t, err = p.unary(); err == nil {
+				r = Mul(r, t)
+			}
+		case p.accept('/'):
+			if t, err = p.unary(); err == nil {
+				r = Div(r, t)
+			}
+		default:
+			return r, nil
+		}
+	}
+	return Float64{}, err
+}
+
+func (p *parser) unary() (Float64, error) {
+	switch {
+	case p.accept('-'):
+		r, err := p.unary()
+		return Neg(r), err
+	case p.accept('+'):
+		return p.unary()
+	}
+	return p.power()
+}
+
+func (p *parser) power() (Float64, error) {
+	r, err := p.primary()
+	if err != nil || !p.accept('^') {
+		return r, err
+	}
+	e, err := p.unary()
+	if err != nil {
+		return Float64{}, err
+	}
+	return pow(r, e)
+}
+
+func (p *parser) primary() (Float64, error) {
+	c := p.peek()
+	switch {
+	case c == '(':
+		p.pos++
+		r, err := p.expr()
+		if err != nil {
+			return Float64{}, err
+		}
+		if !p.accept(')') {
+			return Float64{}, p.errorf("expected ')'")
+		}
+		return r, nil
+	case c == '.' || '0' <= c && c <= '9':
+		v, err := p.number()
+		if err != nil || p.peek() == 0 || !strings.HasPrefix(p.s[p.pos:], "±") {
+			return New(v, 0), err
+		}
+		p.pos += len("±")
+		p.peek()
+		d, err := p.number()
+		return New(v, d), err
+	case isNameByte(c, false):
+		return p.name()
+	case c == 0:
+		return Float64{}, p.errorf("unexpected end of expression")
+	}
+	return Float64{}, p.errorf("unexpected %q", p.next())
+}
+
+// next returns the next rune of input, without consuming it.
+func (p *parser) next() rune {
+	r, _ := utf8.DecodeRuneInString(p.s[p.pos:])
+	return r
+}
+
+// number parses a number, such as 12, 1.5 or 2e-3.
+func (p *parser) number() (float64, error) {
+	start := p.pos
+	digits := func() {
+		for p.pos < len(p.s) && ('0' <= p.s[p.pos] && p.s[p.pos] <= '9' || p.s[p.pos] == '.') {
+			p.pos++
+		}
+	}
+	digits()
+	if p.pos < len(p.s) && (p.s[p.pos] == 'e' || p.s[p.pos] == 'E') {
+		p.pos++
+		if p.pos < len(p.s) && (p.s[p.pos] == '+' || p.s[p.pos] == '-') {
+			p.pos++
+		}
+		digits()
+	}
+	num := p.s[start:p.pos]
+	v, err := strconv.ParseFloat(num, 64)
+	if err != nil {
+		p.pos = start
+		return 0, p.errorf("invalid number %q", num)
+	}
+	return v, nil
+}
+
+// name parses a variable, or a function call.
+func (p *parser) name() (Float64, error) {
+	start := p.pos
+	for p.pos < len(p.s) && isNameByte(p.s[p.pos], true) {
+		p.pos++
+	}
+	name := p.s[start:p.pos]
+	if !p.accept('(') {
+		if v, ok := p.vars[name]; ok {
+			return v, nil
+		}
+		switch name {
+		case "pi":
+			return New(math.Pi, 0), nil
+		case "e":
+			return New(math.E, 0), nil
+		}
+		return Float64{}, fmt.Errorf("variable %q: %w", name, ErrUndefined)
+	}
+	f, ok := evalFuncs[strings.ToLower(name)]
+	if !ok {
+		return Float64{}, fmt.Errorf("function %q: %w", name, ErrUndefined)
+	}
+	var args []Float64
+	for !p.accept(')') {
+		if len(args) > 0 && !p.accept(',') {
+			return Float64{}, p.errorf("expected ',' or ')'")
+		}
+		a, err := p.expr()
+		if err != nil {
+			return Float64{}, err
+		}
+		args = append(args, a)
+	}
+	r, err := f(args)
+	if err != nil {
+		return Float64{}, fmt.Errorf("%v: %w", name, err)
+	}
+	return r, nil
+}
+
+// isNameByte returns true if c can appear in a name, which is made of ASCII
+// letters, underscores and, other than first, digits.
+func isNameByte(c byte, digits bool) bool {
+	return c == '_' || 'a' <= c && c <= 'z' || 'A' <= c && c <= 'Z' || digits && '0' <= c && c <= '9'
+}
+
+// pow computes a^b.  For an exact integer b, this is PowInt.
+//
+// Otherwise, based on first order Taylor expansion around (a, b):
+//   d(a^b) = |b*a^(b-1)|*da + |a^b*log(a)|*db
+// Returns an error wrapping ErrDomain if the interval of a reaches below
+// zero.
+func pow(a, b Float64) (Float64, error) {
+	if b.delta == 0 && b.val == math.Trunc(b.val) && math.Abs(b.val) <= math.MaxInt32 {
+		return PowInt(a, int(b.val)), nil
+	}
+	if a.Min() < 0 {
+		return Float64{}, fmt.Errorf("could not compute %v^%v: %w", a, b, ErrDomain)
+	}
+	return ApplyNGrad(func(x ...float64) float64 {
+		return math.Pow(x[0], x[1])
+	}, func(x ...float64) []float64 {
+		return []float64{x[1] * math.Pow(x[0], x[1]-1), math.Pow(x[0], x[1]) * math.Log(x[0])}
+	}, a, b), nil
+}
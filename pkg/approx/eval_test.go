@@ -0,0 +1,70 @@
+package approx
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestEval(t *testing.T) {
+	t.Parallel()
+	vars := map[string]Float64{
+		"w":  New(3, 0.1),
+		"l":  New(4, 0.2),
+		"x":  New(2, 0.01),
+		"e":  New(1, 0.5),
+		"_n": New(-1, 0.5),
+	}
+	tests := []struct {
+		expr     string
+		expected Float64
+		err      error
+	}{
+		{expr: "2*(w+l)", expected: New(14, 0.6000000000000001)},
+		{expr: "w + l*2", expected: New(11, 0.5)},
+		{expr: "w - l - 1", expected: New(-2, 0.30000000000000004)},
+		{expr: "l / 2 / 2", expected: New(1, 0.05)},
+		{expr: "-w", expected: New(-3, 0.1)},
+		{expr: "+-w", expected: New(-3, 0.1)},
+		{expr: "x^2", expected: PowInt(New(2, 0.01), 2)},
+		{expr: "2^3^2", expected: New(512, 0)},
+		{expr: "-x^2", expected: Neg(PowInt(New(2, 0.01), 2))},
+		{expr: "x^0.5", expected: New(math.Sqrt(2), 0.005/math.Sqrt(2))},
+		{expr: "sqrt(x)", expected: New(math.Sqrt(2), 0.005/math.Sqrt(2))},
+		{expr: "Hypot(w, l)", expected: Hypot(New(3, 0.1), New(4, 0.2))},
+		{expr: "sin(pi/2)", expected: New(1, 0)},
+		{expr: "e", expected: New(1, 0.5)},
+		{expr: "_n", expected: New(-1, 0.5)},
+		{expr: "1.5e1 + .5", expected: New(15.5, 0)},
+		{expr: " ( w ) ", expected: New(3, 0.1)},
+		{expr: "2*(4.2±0.3)", expected: New(8.4, 0.6)},
+		{expr: "w + 1 ± 0.5", expected: New(4, 0.6)},
+		{expr: "1±", err: ErrSyntax},
+		{expr: "w±1", err: ErrSyntax},
+		{expr: "y", err: ErrUndefined},
+		{expr: "foo(w)", err: ErrUndefined},
+		{expr: "sqrt(_n)", err: ErrDomain},
+		{expr: "_n^0.5", err: ErrDomain},
+		{expr: "sqrt(w, l)", err: ErrSyntax},
+		{expr: "hypot(w)", err: ErrSyntax},
+		{expr: "2*(w+l", err: ErrSyntax},
+		{expr: "2*", err: ErrSyntax},
+		{expr: "2 3", err: ErrSyntax},
+		{expr: "1..2", err: ErrSyntax},
+		{expr: "w $ l", err: ErrSyntax},
+		{expr: "", err: ErrSyntax},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.expr, func(t *testing.T) {
+			t.Parallel()
+			actual, err := Eval(test.expr, vars)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if err == nil && !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
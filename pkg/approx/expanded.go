@@ -0,0 +1,51 @@
+package approx
+
+import "math"
+
+// CoverageFactor returns the coverage factor k for which the interval of
+// ±k standard deviations around the mean of a normal distribution contains
+// the fraction level of the probability, for level between 0 and 1.
+//
+// For example CoverageFactor(0.9545) is approximately 2.
+func CoverageFactor(level float64) float64 {
+	return math.Sqrt2 * math.Erfinv(level)
+}
+
+// Expanded returns f with its delta replaced by the expanded uncertainty
+// k*f.StdDev().  For example, k=2 corresponds to the coverage probability of
+// about 95% for a Gaussian.  The returned value has an Unspecified
+// distribution, since its delta is no longer a standard uncertainty.
+func (f Number[T]) Expanded(k float64) Number[T] {
+	return NewNumber[T](float64(f.val), k*f.StdDev())
+}
+
+// AtConfidence returns f with its delta replaced by the half-width of the
+// interval around f.Value() that contains the fraction level of the
+// probability of f's distribution, for level between 0 and 1.
+//
+// Uncertainties of bounded distributions are expanded up to their bounds.
+// Uncertainties with unspecified distribution are treated as Gaussian.
+func (f Number[T]) AtConfidence(level float64) Number[T] {
+	switch f.dist {
+	case Uniform:
+		return NewNumber[T](float64(f.val), level*float64(f.delta))
+	case Triangular:
+		return NewNumber[T](float64(f.val), (1-math.Sqrt(1-level))*float64(f.delta))
+	default:
+		return f.Expanded(CoverageFactor(level))
+	}
+}
+
+// FromExpanded constructs a Gaussian Float64 from the expanded uncertainty u
+// with the coverage factor k, as quoted on calibration certificates, e.g.
+// "10.0012 g, U = 0.0004 g (k = 2)".
+func FromExpanded(val, u, k float64) Float64 {
+	return New(val, u/k).WithDistribution(Gaussian)
+}
+
+// FromConfidence constructs a Gaussian Float64 from the expanded uncertainty
+// u which corresponds to the coverage probability level, e.g. "10.0012 g,
+// ±0.0004 g at 95% confidence".
+func FromConfidence(val, u, level float64) Float64 {
+	return FromExpanded(val, u, CoverageFactor(level))
+}
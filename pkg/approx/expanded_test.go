@@ -0,0 +1,61 @@
+package approx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestExpanded(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		actual   Float64
+		expected Float64
+	}{
+		{
+			name:     "k=2",
+			actual:   New(10, 0.5).WithDistribution(Gaussian).Expanded(2),
+			expected: New(10, 1),
+		},
+		{
+			name:     "k=2 uniform",
+			actual:   New(10, math.Sqrt(3)).WithDistribution(Uniform).Expanded(2),
+			expected: New(10, 2),
+		},
+		{
+			name:     "gaussian 95%",
+			actual:   New(10, 1).AtConfidence(0.95),
+			expected: New(10, 1.959963984540054),
+		},
+		{
+			name:     "uniform 95%",
+			actual:   New(10, 1).WithDistribution(Uniform).AtConfidence(0.95),
+			expected: New(10, 0.95),
+		},
+		{
+			name:     "triangular 75%",
+			actual:   New(10, 1).WithDistribution(Triangular).AtConfidence(0.75),
+			expected: New(10, 0.5),
+		},
+		{
+			name:     "from expanded",
+			actual:   FromExpanded(10, 1, 2),
+			expected: New(10, 0.5).WithDistribution(Gaussian),
+		},
+		{
+			name:     "from confidence",
+			actual:   FromConfidence(10, 1.959963984540054, 0.95),
+			expected: New(10, 1).WithDistribution(Gaussian),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if test.actual.Distribution() != test.expected.Distribution() ||
+				test.actual.Value() != test.expected.Value() ||
+				math.Abs(test.actual.Delta()-test.expected.Delta()) > 1e-12 {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,213 @@
+// Package fit fits models to measurement data with uncertainties, and returns
+// the fitted parameters as approximate numbers.
+//
+// Example:
+//
+//     x := []float64{1, 2, 3, 4}
+//     y := []approx.Float64{
+//         approx.New(2.1, 0.1), approx.New(3.9, 0.1),
+//         approx.New(6.2, 0.2), approx.New(7.9, 0.2)}
+//     slope, intercept, err := fit.LinFit(x, y)
+package fit
+
+import (
+	"fmt"
+	"math"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+// LinFit fits the straight line y = slope*x + intercept to the points (x, y)
+// by weighted least squares.  Each point is weighted by 1/s^2, where s is the
+// standard uncertainty of its y, see approx.Float64.StdDev.  The deltas of the
+// returned coefficients are their standard uncertainties, which are
+// correlated; see LinFitMultivariate to keep the correlation.
+//
+// If all y are exact, the points are weighted equally, and the uncertainty of
+// the points is estimated from the scatter of the points around the line.
+func LinFit(x []float64, y []approx.Float64) (slope, intercept approx.Float64, err error) {
+	m, err := LinFitMultivariate(x, y)
+	if err != nil {
+		return approx.Float64{}, approx.Float64{}, err
+	}
+	return m.At(0), m.At(1), nil
+}
+
+// LinFitMultivariate fits a straight line to the points (x, y), same as
+// LinFit, and returns the slope and the intercept, in that order, together
+// with their covariance matrix.
+func LinFitMultivariate(x []float64, y []approx.Float64) (approx.Multivariate, error) {
+	n := len(x)
+	if len(y) != n {
+		return approx.Multivariate{}, fmt.Errorf("x and y must have the same length: len(x)=%v, len(y)=%v", n, len(y))
+	}
+	if n < 2 {
+		return approx.Multivariate{}, fmt.Errorf("need at least 2 points to fit a line, have: %v", n)
+	}
+	vals := make([]float64, n)
+	variances := make([]float64, n)
+	for i, yi := range y {
+		vals[i] = yi.Value()
+		variances[i] = yi.StdDev() * yi.StdDev()
+	}
+	return lineFit(x, vals, variances)
+}
+
+// ODR fits the straight line y = slope*x + intercept to the points (x, y),
+// where both coordinates are uncertain.  Unlike LinFit, which only accounts
+// for the uncertainty of y, ODR also accounts for the uncertainty of x.
+//
+// Uses the iterative method of York et al. (2004), which minimizes the sum of
+// the squared distances of the points from the line, each weighted by the
+// uncertainties of the point's coordinates.  The deltas of the returned
+// coefficients are their standard uncertainties.
+func ODR(x, y []approx.Float64) (slope, intercept approx.Float64, err error) {
+	m, err := ODRMultivariate(x, y)
+	if err != nil {
+		return approx.Float64{}, approx.Float64{}, err
+	}
+	return m.At(0), m.At(1), nil
+}
+
+// odrIterations is the maximal number of iterations in ODRMultivariate.
+const odrIterations = 100
+
+// ODRMultivariate fits a straight line to the points (x, y), same as ODR,
+// and returns the slope and the intercept, in that order, together with their
+// covariance matrix.
+func ODRMultivariate(x, y []approx.Float64) (approx.Multivariate, error) {
+	n := len(x)
+	if len(y) != n {
+		return approx.Multivariate{}, fmt.Errorf("x and y must have the same length: len(x)=%v, len(y)=%v", n, len(y))
+	}
+	if n < 2 {
+		return approx.Multivariate{}, fmt.Errorf("need at least 2 points to fit a line, have: %v", n)
+	}
+	xs, ys := make([]float64, n), make([]float64, n)
+	vx, vy := make([]float64, n), make([]float64, n)
+	for i := range x {
+		xs[i], ys[i] = x[i].Value(), y[i].Value()
+		vx[i], vy[i] = x[i].StdDev()*x[i].StdDev(), y[i].StdDev()*y[i].StdDev()
+	}
+	// The initial guess ignores the uncertainties of x.
+	m, err := lineFit(xs, ys, vy)
+	if err != nil {
+		return approx.Multivariate{}, err
+	}
+	b := m.Value(0)
+	w, beta := make([]float64, n), make([]float64, n)
+	var sw, xbar, ybar float64
+	for k := 0; k < odrIterations; k++ {
+		sw, xbar, ybar = 0, 0, 0
+		for i := range w {
+			v := vy[i] + b*b*vx[i]
+			if v == 0 {
+				if allExact(vx, vy) {
+					// lineFit has estimated the uncertainties from the scatter.
+					return m, nil
+				}
+				return approx.Multivariate{}, fmt.Errorf("either all or none of the points must be exact, point %v is exact", i)
+			}
+			w[i] = 1 / v
+			sw += w[i]
+			xbar += w[i] * xs[i]
+			ybar += w[i] * ys[i]
+		}
+		xbar /= sw
+		ybar /= sw
+		var num, den float64
+		for i := range w {
+			u, v := xs[i]-xbar, ys[i]-ybar
+			beta[i] = w[i] * (u*vy[i] + b*v*vx[i])
+			num += w[i] * beta[i] * v
+			den += w[i] * beta[i] * u
+		}
+		prev := b
+		b = num / den
+		if math.Abs(b-prev) <= 1e-15*math.Abs(b) {
+			break
+		}
+	}
+	// The uncertainties follow from the adjusted x, which are the points on
+	// the line closest to the measured points.
+	var adjbar float64
+	for i := range w {
+		adjbar += w[i] * (xbar + beta[i])
+	}
+	adjbar /= sw
+	var su float64
+	for i := range w {
+		u := xbar + beta[i] - adjbar
+		su += w[i] * u * u
+	}
+	vb := 1 / su
+	cov := [][]float64{
+		{vb, -adjbar * vb},
+		{-adjbar * vb, 1/sw + adjbar*adjbar*vb},
+	}
+	return approx.NewMultivariate([]float64{b, ybar - b*xbar}, cov)
+}
+
+// allExact returns true if all variances are zero.
+func allExact(variances ...[]float64) bool {
+	for _, vs := range variances {
+		for _, v := range vs {
+			if v != 0 {
+				return false
+			}
+		}
+	}
+	return true
+}
+
+// lineFit fits a straight line to the points (x, y) by least squares,
+// weighting each point by the inverse of its variance.  If all variances are
+// zero, the points are weighted equally and the variance is estimated from
+// the scatter of the points around the line.
+func lineFit(x, y, variances []float64) (approx.Multivariate, error) {
+	n := len(x)
+	w := make([]float64, n)
+	exact := 0
+	for i, v := range variances {
+		if v != 0 {
+			w[i] = 1 / v
+		} else {
+			w[i] = 1
+			exact++
+		}
+	}
+	if exact != 0 && exact != n {
+		return approx.Multivariate{}, fmt.Errorf("either all or none of the points must be exact, %v of %v are exact", exact, n)
+	}
+	var s, sx, sy, sxx, sxy float64
+	for i := range x {
+		s += w[i]
+		sx += w[i] * x[i]
+		sy += w[i] * y[i]
+		sxx += w[i] * x[i] * x[i]
+		sxy += w[i] * x[i] * y[i]
+	}
+	d := s*sxx - sx*sx
+	if d == 0 {
+		return approx.Multivariate{}, fmt.Errorf("could not fit line: all x are equal")
+	}
+	slope := (s*sxy - sx*sy) / d
+	intercept := (sxx*sy - sx*sxy) / d
+	scale := 1.0
+	if exact == n {
+		// Estimate the variance of the points from the residuals.
+		scale = 0
+		for i := range x {
+			r := y[i] - slope*x[i] - intercept
+			scale += r * r
+		}
+		if n > 2 {
+			scale /= float64(n - 2)
+		}
+	}
+	cov := [][]float64{
+		{scale * s / d, -scale * sx / d},
+		{-scale * sx / d, scale * sxx / d},
+	}
+	return approx.NewMultivariate([]float64{slope, intercept}, cov)
+}
@@ -0,0 +1,196 @@
+package fit
+
+import (
+	"math"
+	"testing"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+// near returns true if a and b have close values and deltas.
+func near(a, b approx.Float64) bool {
+	return math.Abs(a.Value()-b.Value()) < 1e-6 && math.Abs(a.Delta()-b.Delta()) < 1e-6
+}
+
+func TestLinFit(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name             string
+		x                []float64
+		y                []approx.Float64
+		slope, intercept approx.Float64
+		err              bool
+	}{
+		{
+			name:      "equal weights",
+			x:         []float64{0, 1, 2},
+			y:         []approx.Float64{approx.New(1, 0.1), approx.New(3, 0.1), approx.New(5, 0.1)},
+			slope:     approx.New(2, 0.1/math.Sqrt(2)),
+			intercept: approx.New(1, 0.1*math.Sqrt(5.0/6)),
+		},
+		{
+			name: "weighted",
+			x:    []float64{0, 1, 2},
+			// The last point is off the line, but has a large uncertainty.
+			y:         []approx.Float64{approx.New(1, 0.01), approx.New(3, 0.01), approx.New(4, 100)},
+			slope:     approx.New(2, 0.01*math.Sqrt(2)),
+			intercept: approx.New(1, 0.01),
+		},
+		{
+			name:      "exact points",
+			x:         []float64{0, 1, 2, 3},
+			y:         []approx.Float64{approx.New(0, 0), approx.New(1, 0), approx.New(1, 0), approx.New(2, 0)},
+			slope:     approx.New(0.6, math.Sqrt(0.1/5*1)),
+			intercept: approx.New(0.1, math.Sqrt(0.1*14/20)),
+		},
+		{
+			name: "length mismatch",
+			x:    []float64{0, 1},
+			y:    []approx.Float64{approx.New(0, 1)},
+			err:  true,
+		},
+		{
+			name: "one point",
+			x:    []float64{0},
+			y:    []approx.Float64{approx.New(0, 1)},
+			err:  true,
+		},
+		{
+			name: "vertical",
+			x:    []float64{1, 1},
+			y:    []approx.Float64{approx.New(0, 1), approx.New(1, 1)},
+			err:  true,
+		},
+		{
+			name: "mixed exact",
+			x:    []float64{0, 1},
+			y:    []approx.Float64{approx.New(0, 0), approx.New(1, 1)},
+			err:  true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			slope, intercept, err := LinFit(test.x, test.y)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if !near(slope, test.slope) || !near(intercept, test.intercept) {
+				t.Errorf("expected: %v, %v, actual: %v, %v", test.slope, test.intercept, slope, intercept)
+			}
+		})
+	}
+}
+
+func TestLinFitMultivariate(t *testing.T) {
+	t.Parallel()
+	x := []float64{0, 1, 2}
+	y := []approx.Float64{approx.New(1, 0.1), approx.New(3, 0.1), approx.New(5, 0.1)}
+	m, err := LinFitMultivariate(x, y)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	// The prediction at x=1 is less uncertain than slope and intercept
+	// treated as independent would suggest.
+	at1 := m.Propagate(func(p []float64) float64 { return p[0]*1 + p[1] })
+	if expected := approx.New(3, 0.1/math.Sqrt(3)); !near(at1, expected) {
+		t.Errorf("expected: %v, actual: %v", expected, at1)
+	}
+	if c := m.Covariance(0, 1); !(c < 0) {
+		t.Errorf("expected negative covariance of slope and intercept, actual: %v", c)
+	}
+}
+
+func TestODR(t *testing.T) {
+	t.Parallel()
+	exact := func(xs ...float64) []approx.Float64 {
+		r := make([]approx.Float64, len(xs))
+		for i, x := range xs {
+			r[i] = approx.New(x, 0)
+		}
+		return r
+	}
+	tests := []struct {
+		name             string
+		x, y             []approx.Float64
+		slope, intercept approx.Float64
+		err              bool
+	}{
+		{
+			name:      "exact x",
+			x:         exact(0, 1, 2),
+			y:         []approx.Float64{approx.New(1, 0.1), approx.New(3, 0.1), approx.New(5, 0.1)},
+			slope:     approx.New(2, 0.1/math.Sqrt(2)),
+			intercept: approx.New(1, 0.1*math.Sqrt(5.0/6)),
+		},
+		{
+			name:      "uncertain x",
+			x:         []approx.Float64{approx.New(0, 0.1), approx.New(1, 0.1), approx.New(2, 0.1)},
+			y:         []approx.Float64{approx.New(1, 0.1), approx.New(3, 0.1), approx.New(5, 0.1)},
+			slope:     approx.New(2, math.Sqrt(0.025)),
+			intercept: approx.New(1, math.Sqrt(100.0/2400)),
+		},
+		{
+			name:      "exact",
+			x:         exact(0, 1, 2, 3),
+			y:         exact(0, 1, 1, 2),
+			slope:     approx.New(0.6, math.Sqrt(0.1/5*1)),
+			intercept: approx.New(0.1, math.Sqrt(0.1*14/20)),
+		},
+		{
+			name: "mixed exact",
+			x:    []approx.Float64{approx.New(0, 0), approx.New(1, 0.1), approx.New(2, 0.1)},
+			y:    exact(0, 1, 2),
+			err:  true,
+		},
+		{
+			name: "length mismatch",
+			x:    exact(0, 1),
+			y:    exact(0),
+			err:  true,
+		},
+		{
+			name: "one point",
+			x:    exact(0),
+			y:    exact(0),
+			err:  true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			slope, intercept, err := ODR(test.x, test.y)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if err != nil {
+				return
+			}
+			if !near(slope, test.slope) || !near(intercept, test.intercept) {
+				t.Errorf("expected: %v, %v, actual: %v, %v", test.slope, test.intercept, slope, intercept)
+			}
+		})
+	}
+}
+
+func TestODRSymmetric(t *testing.T) {
+	t.Parallel()
+	// With equal uncertainties on both axes, fitting x against y gives the
+	// inverse slope of fitting y against x, which LinFit does not.
+	x := []approx.Float64{approx.New(0, 0.1), approx.New(1, 0.1), approx.New(2, 0.1), approx.New(3, 0.1)}
+	y := []approx.Float64{approx.New(0.2, 0.1), approx.New(0.9, 0.1), approx.New(2.3, 0.1), approx.New(2.8, 0.1)}
+	yx, _, err := ODR(x, y)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	xy, _, err := ODR(y, x)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if math.Abs(yx.Value()*xy.Value()-1) > 1e-9 {
+		t.Errorf("expected inverse slopes, actual: %v, %v", yx, xy)
+	}
+}
@@ -0,0 +1,34 @@
+package approx
+
+// Flag is a Float64 which implements flag.Value, and the Value interface of
+// github.com/spf13/pflag, so that programs can accept approximate numbers on
+// the command line, in any form accepted by Parse.
+//
+// Example:
+//     threshold := approx.New(10, 0.5)
+//     flag.Var((*approx.Flag)(&threshold), "threshold", "the threshold")
+//     flag.Parse() // accepts e.g. -threshold=10±0.5, or -threshold=10+-0.5
+type Flag Float64
+
+// String implements flag.Value.
+func (f *Flag) String() string {
+	if f == nil {
+		return Float64{}.String()
+	}
+	return Float64(*f).String()
+}
+
+// Set implements flag.Value.
+func (f *Flag) Set(s string) error {
+	v, err := Parse(s)
+	if err != nil {
+		return err
+	}
+	*f = Flag(v)
+	return nil
+}
+
+// Type implements the Value interface of github.com/spf13/pflag.
+func (f *Flag) Type() string {
+	return "approx"
+}
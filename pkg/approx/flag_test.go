@@ -0,0 +1,57 @@
+package approx
+
+import (
+	"bytes"
+	"flag"
+	"strings"
+	"testing"
+)
+
+func TestFlag(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		args     []string
+		expected Float64
+		err      bool
+	}{
+		{args: nil, expected: New(10, 0.5)},
+		{args: []string{"-threshold=12±0.25"}, expected: New(12, 0.25)},
+		{args: []string{"-threshold", "12+-0.25"}, expected: New(12, 0.25)},
+		{args: []string{"-threshold=[9, 11]"}, expected: New(10, 1)},
+		{args: []string{"-threshold=12±"}, err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(strings.Join(test.args, " "), func(t *testing.T) {
+			t.Parallel()
+			fs := flag.NewFlagSet("test", flag.ContinueOnError)
+			fs.SetOutput(&bytes.Buffer{})
+			threshold := New(10, 0.5)
+			fs.Var((*Flag)(&threshold), "threshold", "the threshold")
+			err := fs.Parse(test.args)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !test.err && !near(threshold, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, threshold)
+			}
+		})
+	}
+}
+
+func TestFlagDefaults(t *testing.T) {
+	t.Parallel()
+	var out bytes.Buffer
+	fs := flag.NewFlagSet("test", flag.ContinueOnError)
+	fs.SetOutput(&out)
+	threshold := New(10, 0.5)
+	f := (*Flag)(&threshold)
+	fs.Var(f, "threshold", "the `threshold`")
+	fs.PrintDefaults()
+	if s := out.String(); !strings.Contains(s, "-threshold threshold") || !strings.Contains(s, "(default 10±0.5)") {
+		t.Errorf("unexpected defaults: %q", s)
+	}
+	if f.Type() != "approx" {
+		t.Errorf("unexpected type: %v", f.Type())
+	}
+}
@@ -0,0 +1,313 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+)
+
+// Format implements fmt.Formatter.
+//
+// The verbs %v and %s without a precision print the same as String.  With a
+// precision, %v rounds the delta to that many significant digits, and the
+// value to the same decimal place, which is the usual way to report a
+// measurement:
+//
+//     fmt.Sprintf("%.2v", approx.New(1.23456, 0.0123)) // 1.235±0.012
+//
+// The verbs %e, %E, %f, %F, %g and %G format both the value and the delta
+// as a float64 would be, e.g. "%.3e".  The flag + prints the sign of the
+// value, and the width and the flag - pad the whole number.
+func (f Number[T]) Format(s fmt.State, verb rune) {
+	var str string
+	prec, hasPrec := s.Precision()
+	switch verb {
+	case 'v', 's':
+		str = f.String()
+		if hasPrec && verb == 'v' {
+			str = roundDigits(f.Float64(), prec)
+		}
+		if s.Flag('+') && f.val >= 0 {
+			str = "+" + str
+		}
+	case 'e', 'E', 'f', 'F', 'g', 'G':
+		format := "%"
+		if hasPrec {
+			format += "." + strconv.Itoa(prec)
+		}
+		format += string(verb)
+		sign := ""
+		if s.Flag('+') {
+			sign = "+"
+		}
+		str = fmt.Sprintf("%"+sign+format[1:]+"±"+format, f.val, f.delta)
+	default:
+		str = fmt.Sprintf("%%!%c(%v)", verb, f.String())
+	}
+	if w, ok := s.Width(); ok {
+		if n := w - len([]rune(str)); n > 0 {
+			if s.Flag('-') {
+				str += strings.Repeat(" ", n)
+			} else {
+				str = strings.Repeat(" ", n) + str
+			}
+		}
+	}
+	fmt.Fprint(s, str)
+}
+
+// roundDigits formats f with the delta rounded to digits significant
+// digits, and the value rounded to the same decimal place.  An exact value is
+// printed with full precision.
+func roundDigits(f Float64, digits int) string {
+	if !finite(f) {
+		return f.String()
+	}
+	val, delta := roundParts(f, digits)
+	return val + "±" + delta
+}
+
+// roundParts returns the value and the delta of the finite f, formatted as
+// by roundDigits.
+func roundParts(f Float64, digits int) (val, delta string) {
+	if digits < 1 {
+		digits = 1
+	}
+	if f.delta == 0 {
+		// An exact value has no insignificant digits to drop.  Print all of
+		// them, without an exponent.
+		return strconv.FormatFloat(f.val, 'f', -1, 64), "0"
+	}
+	place, _ := significant(f.delta, digits)
+	return roundPlace(f, place)
+}
+
+// roundPlace returns the value and the delta of f, each rounded to the
+// decimal place place, e.g. -2 for hundredths.
+func roundPlace(f Float64, place int) (val, delta string) {
+	d := fromPlace(math.Round(toPlace(f.delta, place)), place)
+	if place >= 0 {
+		return fmt.Sprintf("%.0f", fromPlace(math.Round(toPlace(f.val, place)), place)), fmt.Sprintf("%.0f", d)
+	}
+	return fmt.Sprintf("%.*f", -place, f.val), fmt.Sprintf("%.*f", -place, d)
+}
+
+// finite returns true if the value and the delta of f are finite.
+func finite(f Float64) bool {
+	return !math.IsNaN(f.val) && !math.IsInf(f.val, 0) && !math.IsNaN(f.delta) && !math.IsInf(f.delta, 0)
+}
+
+// IntervalString formats f as the interval [min, max] of its extreme values,
+// the form in which tolerances are often specified.  Parse accepts the same
+// form.
+//
+// Example:
+//     approx.New(50, 0.5).IntervalString() // [49.5, 50.5]
+func (f Number[T]) IntervalString() string {
+	return fmt.Sprintf("[%v, %v]", f.Min(), f.Max())
+}
+
+// FormatSI formats f with the value and the delta scaled to the SI prefix
+// which fits the value, and the delta rounded to digits significant digits,
+// see Format.  With a unit, the prefix is written in front of the unit,
+// otherwise after each number:
+//
+//     approx.New(1500, 100).FormatSI(2, "")  // 1.50 k ± 0.10 k
+//     approx.New(1500, 100).FormatSI(2, "Ω") // (1.50±0.10) kΩ
+//
+// Numbers too large or too small for the SI prefixes are written with the
+// largest or the smallest prefix, respectively.
+func (f Number[T]) FormatSI(digits int, unit string) string {
+	w := f.Float64()
+	if !finite(w) {
+		return w.String()
+	}
+	exp := exponent(w, 3)
+	if exp > 30 {
+		exp = 30
+	} else if exp < -30 {
+		exp = -30
+	}
+	prefix := siPrefixes[exp]
+	scale := math.Pow10(exp)
+	val, delta := roundParts(New(w.val/scale, w.delta/scale), digits)
+	if unit == "" {
+		if prefix == "" {
+			return val + " ± " + delta
+		}
+		return fmt.Sprintf("%v %v ± %v %v", val, prefix, delta, prefix)
+	}
+	return fmt.Sprintf("(%v±%v) %v%v", val, delta, prefix, unit)
+}
+
+// FormatEng formats f in the engineering notation, with an exponent which is
+// a multiple of three, shared by the value and the delta.  The delta is
+// rounded to digits significant digits, see Format.  Parse accepts the same
+// form.
+//
+// Example:
+//     approx.New(12345, 678).FormatEng(2) // (12.35±0.68)e3
+//     approx.New(0.5, 0.01).FormatEng(1)  // (500±10)e-3
+func (f Number[T]) FormatEng(digits int) string {
+	w := f.Float64()
+	if !finite(w) {
+		return w.String()
+	}
+	exp := exponent(w, 3)
+	val, delta := roundParts(New(toPlace(w.val, exp), toPlace(w.delta, exp)), digits)
+	if exp == 0 {
+		return val + "±" + delta
+	}
+	return fmt.Sprintf("(%v±%v)e%v", val, delta, exp)
+}
+
+// FormatLaTeX formats f for the LaTeX package siunitx.  Without a unit, f is
+// formatted as a number, otherwise as a quantity with the given siunitx unit.
+// Round f first to drop insignificant digits.
+//
+// Example:
+//     approx.New(4.2, 0.3).FormatLaTeX("")             // \num{4.2 \pm 0.3}
+//     approx.New(4.2, 0.3).FormatLaTeX(`\centi\meter`) // \SI{4.2 \pm 0.3}{\centi\meter}
+func (f Number[T]) FormatLaTeX(unit string) string {
+	num := fmt.Sprintf("%v \\pm %v", f.val, f.delta)
+	if unit == "" {
+		return `\num{` + num + `}`
+	}
+	return `\SI{` + num + `}{` + unit + `}`
+}
+
+// FormatHTML formats f for HTML, with the delta rounded to digits significant
+// digits, see Format.  With sup, numbers which are very large or very small
+// are written with a power of ten, as a superscript.  Otherwise, all numbers
+// are written without an exponent.
+//
+// Example:
+//     approx.New(4.2, 0.3).FormatHTML(1, true)       // 4.2&nbsp;&plusmn;&nbsp;0.3
+//     approx.New(4.2e-31, 3e-32).FormatHTML(1, true) // (4.2&nbsp;&plusmn;&nbsp;0.3)&times;10<sup>-31</sup>
+//     approx.New(4.2e6, 3e5).FormatHTML(1, false)    // 4200000&nbsp;&plusmn;&nbsp;300000
+func (f Number[T]) FormatHTML(digits int, sup bool) string {
+	const pm = "&nbsp;&plusmn;&nbsp;"
+	w := f.Float64()
+	if !finite(w) {
+		return strings.Replace(w.String(), "±", pm, 1)
+	}
+	exp := exponent(w, 1)
+	if !sup || exp >= -5 && exp < 6 {
+		val, delta := roundParts(w, digits)
+		return val + pm + delta
+	}
+	val, delta := roundParts(New(toPlace(w.val, exp), toPlace(w.delta, exp)), digits)
+	return fmt.Sprintf("(%v%v%v)&times;10<sup>%v</sup>", val, pm, delta, exp)
+}
+
+// siPrefixes are the SI prefixes, by their decimal exponent.
+var siPrefixes = map[int]string{
+	-30: "q", -27: "r", -24: "y", -21: "z", -18: "a", -15: "f", -12: "p",
+	-9: "n", -6: "µ", -3: "m", 0: "", 3: "k", 6: "M", 9: "G", 12: "T",
+	15: "P", 18: "E", 21: "Z", 24: "Y", 27: "R", 30: "Q",
+}
+
+// exponent returns the decimal exponent of the value of the finite f, or of
+// its delta if the value is zero, rounded down to a multiple of n.
+func exponent(f Float64, n int) int {
+	x := math.Abs(f.val)
+	if x == 0 {
+		x = f.delta
+	}
+	if x == 0 {
+		return 0
+	}
+	// The decimal exponent of x, which unlike the logarithm is exact, also
+	// for subnormal numbers.
+	e := strconv.FormatFloat(x, 'e', 16, 64)
+	exp, _ := strconv.Atoi(e[strings.IndexByte(e, 'e')+1:])
+	if exp < 0 {
+		exp -= n - 1
+	}
+	return exp / n * n
+}
+
+// significant rounds the positive x to digits significant digits.  Returns
+// the decimal place of the last significant digit, and x in units of that
+// place, which has exactly digits digits.
+func significant(x float64, digits int) (place int, units float64) {
+	place = int(math.Floor(math.Log10(x))) - digits + 1
+	// Correct for the rounding of the logarithm, and for the rounding of x
+	// carrying into a new digit.
+	for {
+		units = math.Round(toPlace(x, place))
+		switch {
+		case units >= math.Pow10(digits):
+			place++
+		case units < math.Pow10(digits-1):
+			place--
+		default:
+			return place, units
+		}
+	}
+}
+
+// Concise formats f in the concise notation, in which the delta is written
+// in parentheses, as the uncertainty in the last digits of the value, e.g.
+// "1.2345(12)" for 1.2345±0.0012.  The delta is rounded to digits
+// significant digits, and the value to the same decimal place.
+//
+// Numbers which are very large or very small, or whose delta reaches into
+// the digits before the decimal point, are written in the scientific
+// notation, e.g. the mass of the electron in kg:
+//
+//     approx.New(9.1093837015e-31, 2.8e-40).Concise(2) // 9.1093837015(28)×10⁻³¹
+func (f Number[T]) Concise(digits int) string {
+	w := f.Float64()
+	if digits < 1 {
+		digits = 1
+	}
+	if !finite(w) {
+		return w.String()
+	}
+	if w.delta == 0 {
+		return strconv.FormatFloat(w.val, 'g', -1, 64)
+	}
+	place, u := significant(w.delta, digits)
+	// The value in units of the last digit.
+	val := strconv.FormatFloat(math.Abs(math.Round(toPlace(w.val, place))), 'f', 0, 64)
+	if v := math.Abs(w.val); place < 0 && toPlace(v, place) >= 1<<53 {
+		// The units overflow, or lose digits.  Take the digits of the
+		// value from its decimal expansion instead.
+		val = strings.TrimLeft(strings.Replace(strconv.FormatFloat(v, 'f', -place, 64), ".", "", 1), "0")
+	}
+	sign := ""
+	if w.val < 0 && val != "0" {
+		sign = "-"
+	}
+	unc := "(" + strconv.FormatFloat(u, 'f', 0, 64) + ")"
+	// The exponent of the leading digit.
+	exp := place + len(val) - 1
+	if val == "0" {
+		exp = place + len(unc) - 3
+	}
+	if place <= 0 && exp >= -5 && exp < 6 {
+		if len(val) <= -place {
+			val = strings.Repeat("0", -place-len(val)+1) + val
+		}
+		if place < 0 {
+			val = val[:len(val)+place] + "." + val[len(val)+place:]
+		}
+		return sign + val + unc
+	}
+	val = strings.Repeat("0", exp-place+1-len(val)) + val
+	if len(val) > 1 {
+		val = val[:1] + "." + val[1:]
+	}
+	return sign + val + unc + "×10" + superscript(strconv.Itoa(exp))
+}
+
+// superscript returns s with the digits and the minus sign replaced by their
+// superscript forms.
+func superscript(s string) string {
+	return strings.NewReplacer(
+		"-", "⁻", "0", "⁰", "1", "¹", "2", "²", "3", "³", "4", "⁴",
+		"5", "⁵", "6", "⁶", "7", "⁷", "8", "⁸", "9", "⁹",
+	).Replace(s)
+}
@@ -0,0 +1,241 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"testing"
+)
+
+func TestFormat(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		format   string
+		arg      interface{}
+		expected string
+	}{
+		{format: "%v", arg: New(1.23456, 0.0123), expected: "1.23456±0.0123"},
+		{format: "%s", arg: New(1.23456, 0.0123), expected: "1.23456±0.0123"},
+		{format: "%.2v", arg: New(1.23456, 0.0123), expected: "1.235±0.012"},
+		{format: "%.1v", arg: New(1.23456, 0.0123), expected: "1.23±0.01"},
+		{format: "%.1v", arg: New(1.23456, 0.0996), expected: "1.2±0.1"},
+		{format: "%.2v", arg: New(12345, 678), expected: "12350±680"},
+		{format: "%.1v", arg: New(-12345, 678), expected: "-12300±700"},
+		{format: "%.2v", arg: New(3.14159, 0), expected: "3.14159±0"},
+		{format: "%.1v", arg: New(1234.5, 0), expected: "1234.5±0"},
+		{format: "%.2v", arg: New(math.Inf(1), 1), expected: "+Inf±1"},
+		{format: "%.2v", arg: New(5e-324, 5e-324), expected: "0." + strings.Repeat("0", 323) + "49±0." + strings.Repeat("0", 323) + "49"},
+		{format: "%.1v", arg: New(1, 1e-320), expected: "1." + strings.Repeat("0", 320) + "±0." + strings.Repeat("0", 319) + "1"},
+		{format: "%.0v", arg: New(1.23456, 0.0123), expected: "1.23±0.01"},
+		{format: "%+v", arg: New(1, 0.5), expected: "+1±0.5"},
+		{format: "%+.1v", arg: New(-1, 0.5), expected: "-1.0±0.5"},
+		{format: "%.2e", arg: New(1234.5, 6.7), expected: "1.23e+03±6.70e+00"},
+		{format: "%E", arg: New(1234.5, 6.7), expected: "1.234500E+03±6.700000E+00"},
+		{format: "%.3f", arg: New(1.23456, 0.0123), expected: "1.235±0.012"},
+		{format: "%+.1f", arg: New(1.23456, 0.0123), expected: "+1.2±0.0"},
+		{format: "%g", arg: New(1.23456, 0.0123), expected: "1.23456±0.0123"},
+		{format: "%.3G", arg: New(1.23456e-10, 1e-12), expected: "1.23E-10±1E-12"},
+		{format: "%12v", arg: New(1, 0.5), expected: "       1±0.5"},
+		{format: "%-12v|", arg: New(1, 0.5), expected: "1±0.5       |"},
+		{format: "%3v", arg: New(1, 0.5), expected: "1±0.5"},
+		{format: "%d", arg: New(1, 0.5), expected: "%!d(1±0.5)"},
+		{format: "%v", arg: NewNumber[float32](0.1, 0.01), expected: "0.1±0.010000002"},
+		{format: "%.2f", arg: NewNumber[float32](0.1, 0.01), expected: "0.10±0.01"},
+		{format: "%v", arg: []Float64{New(1, 0.5)}, expected: "[1±0.5]"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.format, func(t *testing.T) {
+			t.Parallel()
+			if actual := fmt.Sprintf(test.format, test.arg); actual != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestConcise(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f        Float64
+		digits   int
+		expected string
+	}{
+		{f: New(1.2345, 0.0012), digits: 2, expected: "1.2345(12)"},
+		{f: New(1.23456, 0.0012), digits: 1, expected: "1.235(1)"},
+		{f: New(-1.23456, 0.0012), digits: 1, expected: "-1.235(1)"},
+		{f: New(1.23456, 0.0096), digits: 1, expected: "1.23(1)"},
+		{f: New(1.23456, 0.0012), digits: 0, expected: "1.235(1)"},
+		{f: New(123.45, 0.5), digits: 1, expected: "123.5(5)"},
+		{f: New(123.45, 5), digits: 1, expected: "123(5)"},
+		{f: New(12345, 678), digits: 2, expected: "1.235(68)×10⁴"},
+		{f: New(0.000123, 0.000004), digits: 1, expected: "0.000123(4)"},
+		{f: New(0.001, 0.5), digits: 1, expected: "0.0(5)"},
+		{f: New(-0.001, 0.5), digits: 1, expected: "0.0(5)"},
+		{f: New(1e-3, 5e3), digits: 1, expected: "0(5)×10³"},
+		{f: New(9.1093837015e-31, 2.8e-40), digits: 2, expected: "9.1093837015(28)×10⁻³¹"},
+		{f: New(6.02214076e23, 1e15), digits: 1, expected: "6.02214076(1)×10²³"},
+		{f: New(5e-324, 5e-324), digits: 1, expected: "5(5)×10⁻³²⁴"},
+		{f: New(5e-324, 5e-324), digits: 2, expected: "4.9(49)×10⁻³²⁴"},
+		{f: New(1e-310, 1e-320), digits: 1, expected: "1.0000000000(1)×10⁻³¹⁰"},
+		{f: New(1, 1e-320), digits: 1, expected: "1." + strings.Repeat("0", 320) + "(1)"},
+		{f: New(1.5, 0), digits: 2, expected: "1.5"},
+		{f: New(math.NaN(), 1), digits: 2, expected: "NaN±1"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			if actual := test.f.Concise(test.digits); actual != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIntervalString(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f        Float64
+		expected string
+	}{
+		{f: New(50, 0.5), expected: "[49.5, 50.5]"},
+		{f: New(-1, 0), expected: "[-1, -1]"},
+		{f: New(0, 1e-3), expected: "[-0.001, 0.001]"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			actual := test.f.IntervalString()
+			if actual != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, actual)
+			}
+			if back, err := Parse(actual); err != nil || !near(back, test.f) {
+				t.Errorf("expected: %v, actual: %v, err: %v", test.f, back, err)
+			}
+		})
+	}
+}
+
+func TestFormatSI(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f        Float64
+		digits   int
+		unit     string
+		expected string
+	}{
+		{f: New(1500, 100), digits: 2, expected: "1.50 k ± 0.10 k"},
+		{f: New(1500, 100), digits: 2, unit: "Ω", expected: "(1.50±0.10) kΩ"},
+		{f: New(1500, 100), digits: 1, unit: "Ω", expected: "(1.5±0.1) kΩ"},
+		{f: New(4.7e-6, 0.47e-6), digits: 1, unit: "F", expected: "(4.7±0.5) µF"},
+		{f: New(-0.0123, 0.0002), digits: 1, unit: "A", expected: "(-12.3±0.2) mA"},
+		{f: New(12, 0.5), digits: 1, expected: "12.0 ± 0.5"},
+		{f: New(12, 0.5), digits: 1, unit: "m", expected: "(12.0±0.5) m"},
+		{f: New(999, 10), digits: 1, unit: "V", expected: "(1000±10) V"},
+		{f: New(1000, 10), digits: 1, unit: "V", expected: "(1.00±0.01) kV"},
+		{f: New(0, 0.002), digits: 1, unit: "V", expected: "(0±2) mV"},
+		{f: New(0, 0), digits: 1, unit: "V", expected: "(0±0) V"},
+		{f: New(2.2e6, 0), digits: 2, unit: "Ω", expected: "(2.2±0) MΩ"},
+		{f: New(1234.5, 0), digits: 1, unit: "V", expected: "(1.2345±0) kV"},
+		{f: New(2e33, 1e32), digits: 1, unit: "m", expected: "(2000±100) Qm"},
+		{f: New(2e-33, 1e-34), digits: 1, unit: "m", expected: "(0.0020±0.0001) qm"},
+		{f: New(math.Inf(1), 1), digits: 1, unit: "m", expected: "+Inf±1"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			if actual := test.f.FormatSI(test.digits, test.unit); actual != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFormatEng(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f        Float64
+		digits   int
+		expected string
+	}{
+		{f: New(12345, 678), digits: 2, expected: "(12.35±0.68)e3"},
+		{f: New(0.5, 0.01), digits: 1, expected: "(500±10)e-3"},
+		{f: New(-4.7e-9, 1e-10), digits: 1, expected: "(-4.7±0.1)e-9"},
+		{f: New(12, 0.5), digits: 1, expected: "12.0±0.5"},
+		{f: New(0, 2e-6), digits: 1, expected: "(0±2)e-6"},
+		{f: New(1e40, 1e39), digits: 1, expected: "(10±1)e39"},
+		{f: New(2.2e6, 0), digits: 2, expected: "(2.2±0)e6"},
+		{f: New(70000, 0), digits: 1, expected: "(70±0)e3"},
+		{f: New(1e-320, 0), digits: 1, expected: "(9.999888671826831±0)e-321"},
+		{f: New(0, 5e-324), digits: 1, expected: "(0±5)e-324"},
+		{f: New(math.NaN(), 1), digits: 1, expected: "NaN±1"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			actual := test.f.FormatEng(test.digits)
+			if actual != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, actual)
+			}
+			if back, err := Parse(actual); finite(test.f) && (err != nil || math.Abs(back.val-test.f.val) > test.f.delta) {
+				t.Errorf("could not parse back %q: %v, %v", actual, back, err)
+			}
+		})
+	}
+}
+
+func TestFormatLaTeX(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f        Float64
+		unit     string
+		expected string
+	}{
+		{f: New(4.2, 0.3), expected: `\num{4.2 \pm 0.3}`},
+		{f: New(4.2, 0.3), unit: `\centi\meter`, expected: `\SI{4.2 \pm 0.3}{\centi\meter}`},
+		{f: New(-1e-5, 0), unit: `\volt`, expected: `\SI{-1e-05 \pm 0}{\volt}`},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			if actual := test.f.FormatLaTeX(test.unit); actual != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestFormatHTML(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f        Float64
+		digits   int
+		sup      bool
+		expected string
+	}{
+		{f: New(4.2, 0.3), digits: 1, sup: true, expected: "4.2&nbsp;&plusmn;&nbsp;0.3"},
+		{f: New(-123.456, 1.23), digits: 2, sup: true, expected: "-123.5&nbsp;&plusmn;&nbsp;1.2"},
+		{f: New(4.2e-31, 3e-32), digits: 1, sup: true, expected: "(4.2&nbsp;&plusmn;&nbsp;0.3)&times;10<sup>-31</sup>"},
+		{f: New(6.02214076e23, 0), digits: 3, sup: true, expected: "(6.02214076&nbsp;&plusmn;&nbsp;0)&times;10<sup>23</sup>"},
+		{f: New(5e-324, 0), digits: 1, sup: true, expected: "(4.940656458412466&nbsp;&plusmn;&nbsp;0)&times;10<sup>-324</sup>"},
+		{f: New(1e-320, 1e-321), digits: 1, sup: true, expected: "(10&nbsp;&plusmn;&nbsp;1)&times;10<sup>-321</sup>"},
+		{f: New(math.Inf(1), 0), digits: 1, sup: true, expected: "+Inf&nbsp;&plusmn;&nbsp;0"},
+		{f: New(4.2, 0.3), digits: 1, expected: "4.2&nbsp;&plusmn;&nbsp;0.3"},
+		{f: New(4.2e6, 3e5), digits: 1, expected: "4200000&nbsp;&plusmn;&nbsp;300000"},
+		{f: New(4.2e-7, 3e-8), digits: 1, expected: "0.00000042&nbsp;&plusmn;&nbsp;0.00000003"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			if actual := test.f.FormatHTML(test.digits, test.sup); actual != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,479 @@
+package approx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrDomain is returned when the interval of an approximate number reaches
+// outside of the domain of a function.
+var ErrDomain = errors.New("interval outside of the function domain")
+
+// Sqrt computes the square root of f.
+//
+// Based on first order Taylor expansion around x:
+//   sqrt(x+dx) = sqrt(x) + dx/(2*sqrt(x))
+//
+// Returns an error wrapping ErrDomain if the interval of f reaches below
+// zero.
+func Sqrt(f Float64) (Float64, error) {
+	if f.Min() < 0 {
+		return Float64{}, fmt.Errorf("could not compute square root of %v: %w", f, ErrDomain)
+	}
+	if f.delta == 0 {
+		return f.chain(math.Sqrt(f.val), 0), nil
+	}
+	s := math.Sqrt(f.val)
+	return f.chain(s, 0.5/s), nil
+}
+
+// Cbrt computes the cube root of f.
+//
+// Based on first order Taylor expansion around x:
+//   cbrt(x+dx) = cbrt(x) + dx/(3*cbrt(x)^2)
+//
+// The derivative is unbounded at zero, so if the interval of f contains
+// zero, the result is computed from the endpoints of the interval instead,
+// which is exact since the cube root is monotonic.
+func Cbrt(f Float64) Float64 {
+	if f.delta == 0 {
+		return f.chain(math.Cbrt(f.val), 0)
+	}
+	if f.Min() <= 0 && 0 <= f.Max() {
+		return fromMinMax(math.Cbrt(f.Min()), math.Cbrt(f.Max())).WithDistribution(f.dist)
+	}
+	c := math.Cbrt(f.val)
+	return f.chain(c, 1/(3*c*c))
+}
+
+// PowInt computes f raised to the integer power n.
+//
+// Based on first order Taylor expansion around x:
+//   (x+dx)^n = x^n + n*x^(n-1)*dx
+// so the relative error of the result is |n| times the relative error of f.
+// Unlike multiplying f by itself with Mul, this accounts for all the factors
+// being the same measurement.
+//
+// If the value of f is zero or tiny, the first order expansion vanishes, and
+// the result is computed from the endpoints of the interval of f instead.
+func PowInt(f Float64, n int) Float64 {
+	val := math.Pow(f.val, float64(n))
+	switch {
+	case n == 0:
+		return New(1, 0)
+	case f.delta == 0:
+		return f.chain(val, 0)
+	case tiny(f.val) && n > 0:
+		lo, hi := math.Pow(f.Min(), float64(n)), math.Pow(f.Max(), float64(n))
+		if n%2 == 0 {
+			return fromMinMax(0, math.Max(lo, hi)).WithDistribution(f.dist)
+		}
+		return fromMinMax(lo, hi).WithDistribution(f.dist)
+	}
+	return f.chain(val, float64(n)*math.Pow(f.val, float64(n-1)))
+}
+
+// Sin computes the sine of f.
+//
+// Based on first order Taylor expansion around x:
+//   sin(x+dx) = sin(x) + cos(x)*dx
+//
+// Near the peaks of the sine, the first order expansion vanishes.  So if the
+// interval of f contains a peak, the delta is instead the largest deviation
+// from the value over the interval.
+func Sin(f Float64) Float64 {
+	val := math.Sin(f.val)
+	if f.delta != 0 && containsPoint(f.Min(), f.Max(), math.Pi/2, math.Pi) {
+		return New(val, rangeDelta(val, math.Sin, f, math.Pi/2)).WithDistribution(f.dist)
+	}
+	return f.chain(val, math.Cos(f.val))
+}
+
+// Cos computes the cosine of f.
+//
+// Based on first order Taylor expansion around x:
+//   cos(x+dx) = cos(x) - sin(x)*dx
+//
+// Near the peaks of the cosine, the first order expansion vanishes.  So if
+// the interval of f contains a peak, the delta is instead the largest
+// deviation from the value over the interval.
+func Cos(f Float64) Float64 {
+	val := math.Cos(f.val)
+	if f.delta != 0 && containsPoint(f.Min(), f.Max(), 0, math.Pi) {
+		return New(val, rangeDelta(val, math.Cos, f, 0)).WithDistribution(f.dist)
+	}
+	return f.chain(val, -math.Sin(f.val))
+}
+
+// Tan computes the tangent of f.
+//
+// Based on first order Taylor expansion around x:
+//   tan(x+dx) = tan(x) + dx/cos(x)^2
+//
+// If the interval of f contains a pole of the tangent, the result is
+// unbounded, and has an infinite delta.
+func Tan(f Float64) Float64 {
+	val := math.Tan(f.val)
+	if f.delta != 0 && containsPoint(f.Min(), f.Max(), math.Pi/2, math.Pi) {
+		return New(val, math.Inf(1))
+	}
+	c := math.Cos(f.val)
+	return f.chain(val, 1/(c*c))
+}
+
+// Asin computes the arcsine of f.
+//
+// Based on first order Taylor expansion around x:
+//   asin(x+dx) = asin(x) + dx/sqrt(1-x^2)
+//
+// Returns an error wrapping ErrDomain if the interval of f reaches outside
+// of [-1, 1].
+func Asin(f Float64) (Float64, error) {
+	if f.Min() < -1 || 1 < f.Max() {
+		return Float64{}, fmt.Errorf("could not compute arcsine of %v: %w", f, ErrDomain)
+	}
+	if f.delta == 0 {
+		return f.chain(math.Asin(f.val), 0), nil
+	}
+	return f.chain(math.Asin(f.val), 1/math.Sqrt(1-f.val*f.val)), nil
+}
+
+// Acos computes the arccosine of f.
+//
+// Based on first order Taylor expansion around x:
+//   acos(x+dx) = acos(x) - dx/sqrt(1-x^2)
+//
+// Returns an error wrapping ErrDomain if the interval of f reaches outside
+// of [-1, 1].
+func Acos(f Float64) (Float64, error) {
+	if f.Min() < -1 || 1 < f.Max() {
+		return Float64{}, fmt.Errorf("could not compute arccosine of %v: %w", f, ErrDomain)
+	}
+	if f.delta == 0 {
+		return f.chain(math.Acos(f.val), 0), nil
+	}
+	return f.chain(math.Acos(f.val), 1/math.Sqrt(1-f.val*f.val)), nil
+}
+
+// Atan computes the arctangent of f.
+//
+// Based on first order Taylor expansion around x:
+//   atan(x+dx) = atan(x) + dx/(1+x^2)
+func Atan(f Float64) Float64 {
+	return f.chain(math.Atan(f.val), 1/(1+f.val*f.val))
+}
+
+// Atan2 computes the arctangent of y/x, using the signs of the two to
+// determine the quadrant of the result, see math.Atan2.  This converts the
+// uncertain Cartesian coordinates (x, y) to the angle of their polar form.
+//
+// Based on first order Taylor expansion around (x, y):
+//   d(atan2) = (|x|*dy + |y|*dx) / (x^2+y^2)
+//
+// If both intervals contain zero, the point may be anywhere around the
+// origin, and the delta is pi.
+func Atan2(y, x Float64) Float64 {
+	val := math.Atan2(y.val, x.val)
+	if y.Min() <= 0 && 0 <= y.Max() && x.Min() <= 0 && 0 <= x.Max() && (x.delta != 0 || y.delta != 0) {
+		return New(val, math.Pi)
+	}
+	r2 := x.val*x.val + y.val*y.val
+	if r2 == 0 {
+		return New(val, 0)
+	}
+	delta := (math.Abs(x.val)*y.delta + math.Abs(y.val)*x.delta) / r2
+	return New(val, delta).WithDistribution(combineDist(x, y))
+}
+
+// Sinh computes the hyperbolic sine of f.
+//
+// Based on first order Taylor expansion around x:
+//   sinh(x+dx) = sinh(x) + cosh(x)*dx
+func Sinh(f Float64) Float64 {
+	return f.chain(math.Sinh(f.val), math.Cosh(f.val))
+}
+
+// Cosh computes the hyperbolic cosine of f.
+//
+// Based on first order Taylor expansion around x:
+//   cosh(x+dx) = cosh(x) + sinh(x)*dx
+//
+// Around its minimum at zero, the first order expansion vanishes.  So if the
+// interval of f contains zero, the delta is instead the largest deviation
+// from the value over the interval.
+func Cosh(f Float64) Float64 {
+	val := math.Cosh(f.val)
+	if f.delta != 0 && f.Min() <= 0 && 0 <= f.Max() {
+		hi := math.Max(math.Cosh(f.Min()), math.Cosh(f.Max()))
+		return New(val, math.Max(hi-val, val-1)).WithDistribution(f.dist)
+	}
+	return f.chain(val, math.Sinh(f.val))
+}
+
+// Tanh computes the hyperbolic tangent of f.
+//
+// Based on first order Taylor expansion around x:
+//   tanh(x+dx) = tanh(x) + (1-tanh(x)^2)*dx
+func Tanh(f Float64) Float64 {
+	t := math.Tanh(f.val)
+	return f.chain(t, 1-t*t)
+}
+
+// Exp computes e^f.
+//
+// Based on first order Taylor expansion around x:
+//   e^(x+dx) = e^x + e^x*dx
+func Exp(f Float64) Float64 {
+	e := math.Exp(f.val)
+	return f.chain(e, e)
+}
+
+// Expm1 computes e^f-1.  It is more accurate than Exp(f)-1 when the value
+// of f is near zero, see math.Expm1.
+//
+// Based on first order Taylor expansion around x:
+//   e^(x+dx)-1 = e^x-1 + e^x*dx
+func Expm1(f Float64) Float64 {
+	return f.chain(math.Expm1(f.val), math.Exp(f.val))
+}
+
+// Log computes the natural logarithm of f.
+//
+// Based on first order Taylor expansion around x:
+//   ln(x+dx) = ln(x) + dx/x
+//
+// Returns an error wrapping ErrDomain if the interval of f reaches down to
+// zero or below.
+func Log(f Float64) (Float64, error) {
+	return logarithm(f, math.Log, 1, "natural logarithm")
+}
+
+// Log10 computes the decimal logarithm of f.
+//
+// Based on first order Taylor expansion around x:
+//   log10(x+dx) = log10(x) + dx/(x*ln(10))
+//
+// Returns an error wrapping ErrDomain if the interval of f reaches down to
+// zero or below.
+func Log10(f Float64) (Float64, error) {
+	return logarithm(f, math.Log10, math.Ln10, "decimal logarithm")
+}
+
+// Log2 computes the binary logarithm of f.
+//
+// Based on first order Taylor expansion around x:
+//   log2(x+dx) = log2(x) + dx/(x*ln(2))
+//
+// Returns an error wrapping ErrDomain if the interval of f reaches down to
+// zero or below.
+func Log2(f Float64) (Float64, error) {
+	return logarithm(f, math.Log2, math.Ln2, "binary logarithm")
+}
+
+// Log1p computes the natural logarithm of 1+f.  It is more accurate than
+// Log(1+f) when the value of f is near zero, see math.Log1p.
+//
+// Based on first order Taylor expansion around x:
+//   ln(1+x+dx) = ln(1+x) + dx/(1+x)
+//
+// Returns an error wrapping ErrDomain if the interval of f reaches down to
+// -1 or below.
+func Log1p(f Float64) (Float64, error) {
+	if f.Min() <= -1 {
+		return Float64{}, fmt.Errorf("could not compute natural logarithm of 1+%v: %w", f, ErrDomain)
+	}
+	return f.chain(math.Log1p(f.val), 1/(1+f.val)), nil
+}
+
+// logarithm computes the logarithm fx of f, where ln(base) is lnBase.
+func logarithm(f Float64, fx func(float64) float64, lnBase float64, name string) (Float64, error) {
+	if f.Min() <= 0 {
+		return Float64{}, fmt.Errorf("could not compute %v of %v: %w", name, f, ErrDomain)
+	}
+	return f.chain(fx(f.val), 1/(f.val*lnBase)), nil
+}
+
+// Hypot computes sqrt(a^2+b^2), the length of the vector (a, b), see
+// math.Hypot.
+//
+// Based on first order Taylor expansion around (a, b):
+//   d(hypot) = (|a|*da + |b|*db) / hypot(a, b)
+// which accounts for a and b each appearing twice in the formula, unlike
+// computing the same from Mul, Add and Sqrt.
+//
+// If both values are zero, the first order expansion is undefined, and the
+// delta is the largest length that the vector can have.
+func Hypot(a, b Float64) Float64 {
+	h := math.Hypot(a.val, b.val)
+	if h == 0 {
+		return New(0, math.Hypot(a.delta, b.delta))
+	}
+	delta := (math.Abs(a.val)*a.delta + math.Abs(b.val)*b.delta) / h
+	return New(h, delta).WithDistribution(combineDist(a, b))
+}
+
+// Abs computes the absolute value of f.
+//
+// If the interval of f straddles zero, the interval of the result starts at
+// zero, and ends at the larger of the absolute values of f's endpoints.  Note
+// that the value of the result is then the center of that interval, not the
+// absolute value of f's value.
+func Abs(f Float64) Float64 {
+	if f.Min() < 0 && 0 < f.Max() {
+		return fromMinMax(0, math.Max(-f.Min(), f.Max()))
+	}
+	return New(math.Abs(f.val), f.delta).WithDistribution(f.dist)
+}
+
+// Neg computes -f.
+func Neg(f Float64) Float64 {
+	return New(-f.val, f.delta).WithDistribution(f.dist)
+}
+
+// Reciprocal computes 1/f.
+//
+// Based on first order Taylor expansion around x:
+//   1/(x+dx) = 1/x - dx/x^2
+//
+// Returns an error wrapping ErrDivisorContainsZero if the interval of f
+// contains zero, as the reciprocal is then unbounded.
+func Reciprocal(f Float64) (Float64, error) {
+	if f.Min() <= 0 && 0 <= f.Max() {
+		return Float64{}, fmt.Errorf("could not compute reciprocal of %v: %w", f, ErrDivisorContainsZero)
+	}
+	r := 1 / f.val
+	return f.chain(r, r*r), nil
+}
+
+// Max returns the larger of a and b.
+//
+// If a is definitely larger than b, or the other way round, the larger of
+// the two is returned.  Otherwise the intervals overlap, and either may be
+// larger.  The result is then the interval of all possible maxima: from the
+// larger of the minimums to the larger of the maximums.
+func Max(a, b Float64) Float64 {
+	switch {
+	case b.Le(a):
+		return a
+	case a.Le(b):
+		return b
+	}
+	return fromMinMax(math.Max(a.Min(), b.Min()), math.Max(a.Max(), b.Max()))
+}
+
+// Min returns the smaller of a and b.
+//
+// If a is definitely smaller than b, or the other way round, the smaller of
+// the two is returned.  Otherwise the intervals overlap, and either may be
+// smaller.  The result is then the interval of all possible minima: from the
+// smaller of the minimums to the smaller of the maximums.
+func Min(a, b Float64) Float64 {
+	switch {
+	case a.Le(b):
+		return a
+	case b.Le(a):
+		return b
+	}
+	return fromMinMax(math.Min(a.Min(), b.Min()), math.Min(a.Max(), b.Max()))
+}
+
+// Clamp limits x to the range from lo to hi, for example to model a
+// saturating controller whose limits have tolerances.
+//
+// The result is the interval of all values that clamping can produce as x,
+// lo and hi range over their intervals.  If x is definitely within the
+// range, x is returned.  If x is definitely below lo, lo is returned, and if
+// it is definitely above hi, hi is returned.  The result is meaningless if
+// lo is not less than or equal to hi.
+func Clamp(x, lo, hi Float64) Float64 {
+	return Min(Max(x, lo), hi)
+}
+
+// Floor returns the greatest integers less than or equal to the values in the
+// interval of f.
+//
+// The result is the interval of all possible outcomes, e.g. Floor of 3.9±0.2
+// spans from 3 to 4.  The returned flag is true if the outcome is ambiguous,
+// that is if there is more than one possible outcome.
+func Floor(f Float64) (Float64, bool) {
+	return integral(f, math.Floor)
+}
+
+// Ceil returns the least integers greater than or equal to the values in the
+// interval of f.  See Floor for the meaning of the results.
+func Ceil(f Float64) (Float64, bool) {
+	return integral(f, math.Ceil)
+}
+
+// RoundInt returns the nearest integers to the values in the interval of f,
+// rounding half away from zero.  See Floor for the meaning of the results.
+func RoundInt(f Float64) (Float64, bool) {
+	return integral(f, math.Round)
+}
+
+// integral applies the monotonic rounding function fx to the endpoints of f.
+func integral(f Float64, fx func(float64) float64) (Float64, bool) {
+	min, max := fx(f.Min()), fx(f.Max())
+	return fromMinMax(min, max), min != max
+}
+
+// Mod computes the remainder of a/b, with the sign of a, like math.Mod.  This
+// is useful for phase and angle arithmetic.
+//
+// As long as the intervals of a and b do not cross a multiple of b, the
+// remainder is a-n*b for a fixed integer n, and the uncertainty is propagated
+// accordingly.  Otherwise, the remainder wraps around somewhere in the
+// interval, and the result spans all the values it may take, e.g. from 0 to
+// b if a is nonnegative.
+//
+// Example:
+//     approx.Mod(approx.New(7, 0.5), approx.New(3, 0))   // 1±0.5
+//     approx.Mod(approx.New(6, 0.5), approx.New(3, 0))   // 1.5±1.5
+func Mod(a, b Float64) Float64 {
+	q := Interval{}.Div(a, b)
+	if !math.IsInf(q.delta, 0) && math.Trunc(q.Min()) == math.Trunc(q.Max()) {
+		n := math.Trunc(a.val / b.val)
+		r := propagator.Sub(a, b.Mul(n))
+		r.val = math.Mod(a.val, b.val)
+		return r
+	}
+	bmax := math.Max(math.Abs(b.Min()), math.Abs(b.Max()))
+	switch {
+	case a.Min() >= 0:
+		return fromMinMax(0, bmax)
+	case a.Max() <= 0:
+		return fromMinMax(-bmax, 0)
+	default:
+		return New(0, bmax)
+	}
+}
+
+// containsPoint returns true if [min, max] contains a point offset+k*period
+// for some integer k.
+func containsPoint(min, max, offset, period float64) bool {
+	k := math.Ceil((min - offset) / period)
+	return offset+k*period <= max
+}
+
+// rangeDelta returns the largest deviation of fx from val over the interval
+// of f, where fx is a periodic function with period 2*pi, and whose extrema
+// are at offset+k*pi.
+func rangeDelta(val float64, fx func(float64) float64, f Float64, offset float64) float64 {
+	if f.Max()-f.Min() >= 2*math.Pi {
+		return 1 + math.Abs(val)
+	}
+	lo := math.Min(fx(f.Min()), fx(f.Max()))
+	hi := math.Max(fx(f.Min()), fx(f.Max()))
+	for x := offset + math.Ceil((f.Min()-offset)/math.Pi)*math.Pi; x <= f.Max(); x += math.Pi {
+		lo = math.Min(lo, fx(x))
+		hi = math.Max(hi, fx(x))
+	}
+	return math.Max(hi-val, val-lo)
+}
+
+// chain returns an approximate number with value val, for a function of f
+// whose derivative at f's value is df.
+func (f Number[T]) chain(val, df float64) Float64 {
+	return New(val, df*float64(f.delta)).WithDistribution(f.dist)
+}
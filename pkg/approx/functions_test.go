@@ -0,0 +1,461 @@
+package approx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestSqrt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    Float64
+		expected Float64
+		err      error
+	}{
+		{input: New(4, 0.4), expected: New(2, 0.1)},
+		{input: New(0, 0), expected: New(0, 0)},
+		{input: New(1, 1), expected: New(1, 0.5)},
+		{input: New(1, 2), err: ErrDomain},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v", test.input), func(t *testing.T) {
+			actual, err := Sqrt(test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCbrt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    Float64
+		expected Float64
+	}{
+		{input: New(8, 1.2), expected: New(2, 0.09999999999999999)},
+		{input: New(-8, 1.2), expected: New(-2, 0.09999999999999999)},
+		{input: New(0, 0), expected: New(0, 0)},
+		{input: New(0, 8), expected: New(0, 2)},
+		{input: New(-8, 0).WithDistribution(Uniform), expected: New(-2, 0).WithDistribution(Uniform)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v", test.input), func(t *testing.T) {
+			actual := Cbrt(test.input)
+			if !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPowInt(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    Float64
+		n        int
+		expected Float64
+	}{
+		{input: New(10, 0.1), n: 2, expected: New(100, 2)},
+		{input: New(10, 0.1), n: 3, expected: New(1000, 30)},
+		{input: New(-10, 0.1), n: 3, expected: New(-1000, 30)},
+		{input: New(10, 0.1), n: 1, expected: New(10, 0.1)},
+		{input: New(10, 0.1), n: 0, expected: New(1, 0)},
+		{input: New(10, 0.1), n: -1, expected: New(0.1, 0.001)},
+		{input: New(0, 2), n: 2, expected: New(2, 2)},
+		{input: New(0, 2), n: 3, expected: New(0, 8)},
+		{input: New(3, 0), n: 2, expected: New(9, 0)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v^%v", test.input, test.n), func(t *testing.T) {
+			actual := PowInt(test.input, test.n)
+			if !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTrig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		actual   Float64
+		expected Float64
+	}{
+		{
+			name:     "sin(0)",
+			actual:   Sin(New(0, 0.1)),
+			expected: New(0, 0.1),
+		},
+		{
+			name:     "sin(pi/2)",
+			actual:   Sin(New(math.Pi/2, 0.1)),
+			expected: New(1, 1-math.Cos(0.1)),
+		},
+		{
+			name:     "sin wide",
+			actual:   Sin(New(0, 4)),
+			expected: New(0, 1),
+		},
+		{
+			name:     "sin(pi/2) exact",
+			actual:   Sin(New(math.Pi/2, 0)),
+			expected: New(1, 0),
+		},
+		{
+			name:     "cos(0)",
+			actual:   Cos(New(0, 0.1)),
+			expected: New(1, 1-math.Cos(0.1)),
+		},
+		{
+			name:     "cos(pi)",
+			actual:   Cos(New(math.Pi, 0.1)),
+			expected: New(-1, 1-math.Cos(0.1)),
+		},
+		{
+			name:     "cos(pi/2)",
+			actual:   Cos(New(math.Pi/2, 0.1)),
+			expected: New(math.Cos(math.Pi/2), 0.1),
+		},
+		{
+			name:     "tan(0)",
+			actual:   Tan(New(0, 0.1)),
+			expected: New(0, 0.1),
+		},
+		{
+			name:     "tan(pi/2)",
+			actual:   Tan(New(1.5, 0.1)),
+			expected: New(math.Tan(1.5), math.Inf(1)),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if !near(test.actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
+
+// near returns true if a and b have values and deltas equal within rounding
+// errors.
+func near(a, b Float64) bool {
+	eq := func(x, y float64) bool {
+		return x == y || math.Abs(x-y) <= 1e-12*math.Max(1, math.Abs(y))
+	}
+	return eq(a.val, b.val) && eq(a.delta, b.delta) && a.dist == b.dist
+}
+
+func TestInverseTrig(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		f        func(Float64) (Float64, error)
+		input    Float64
+		expected Float64
+		err      error
+	}{
+		{name: "asin(0)", f: Asin, input: New(0, 0.1), expected: New(0, 0.1)},
+		{name: "asin(0.6)", f: Asin, input: New(0.6, 0.08), expected: New(math.Asin(0.6), 0.1)},
+		{name: "asin(1)", f: Asin, input: New(1, 0), expected: New(math.Pi/2, 0)},
+		{name: "asin out of domain", f: Asin, input: New(0.95, 0.1), err: ErrDomain},
+		{name: "acos(0)", f: Acos, input: New(0, 0.1), expected: New(math.Pi/2, 0.1)},
+		{name: "acos(-0.6)", f: Acos, input: New(-0.6, 0.08), expected: New(math.Acos(-0.6), 0.1)},
+		{name: "acos out of domain", f: Acos, input: New(-0.95, 0.1), err: ErrDomain},
+		{
+			name: "atan(1)",
+			f: func(f Float64) (Float64, error) {
+				return Atan(f), nil
+			},
+			input:    New(1, 0.2),
+			expected: New(math.Pi/4, 0.1),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.f(test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAtan2(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		y, x     Float64
+		expected Float64
+	}{
+		{y: New(1, 0), x: New(1, 0), expected: New(math.Pi/4, 0)},
+		{y: New(0, 0.1), x: New(1, 0), expected: New(0, 0.1)},
+		{y: New(1, 0.1), x: New(1, 0.1), expected: New(math.Pi/4, 0.1)},
+		{y: New(3, 0.5), x: New(-4, 0), expected: New(math.Atan2(3, -4), 0.08)},
+		{y: New(0, 0.1), x: New(0, 0.1), expected: New(0, math.Pi)},
+		{y: New(0, 0), x: New(0, 0), expected: New(0, 0)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("(%v;%v)", test.y, test.x), func(t *testing.T) {
+			if actual := Atan2(test.y, test.x); !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHyperbolic(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		actual   Float64
+		expected Float64
+	}{
+		{name: "sinh(0)", actual: Sinh(New(0, 0.1)), expected: New(0, 0.1)},
+		{name: "sinh(1)", actual: Sinh(New(1, 0.1)), expected: New(math.Sinh(1), 0.1*math.Cosh(1))},
+		{name: "cosh(1)", actual: Cosh(New(1, 0.1)), expected: New(math.Cosh(1), 0.1*math.Sinh(1))},
+		{name: "cosh(0)", actual: Cosh(New(0, 0.1)), expected: New(1, math.Cosh(0.1)-1)},
+		{name: "cosh(0) exact", actual: Cosh(New(0, 0)), expected: New(1, 0)},
+		{name: "tanh(0)", actual: Tanh(New(0, 0.1)), expected: New(0, 0.1)},
+		{name: "tanh(-1)", actual: Tanh(New(-1, 0.1)), expected: New(math.Tanh(-1), 0.1*(1-math.Tanh(1)*math.Tanh(1)))},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if !near(test.actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
+
+func TestExpLog(t *testing.T) {
+	t.Parallel()
+	noErr := func(f func(Float64) Float64) func(Float64) (Float64, error) {
+		return func(x Float64) (Float64, error) { return f(x), nil }
+	}
+	tests := []struct {
+		name     string
+		f        func(Float64) (Float64, error)
+		input    Float64
+		expected Float64
+		err      error
+	}{
+		{name: "exp(0)", f: noErr(Exp), input: New(0, 0.1), expected: New(1, 0.1)},
+		{name: "expm1(1e-20)", f: noErr(Expm1), input: New(1e-20, 1e-21), expected: New(1e-20, 1e-21)},
+		{name: "log(e)", f: Log, input: New(math.E, 0.1), expected: New(1, 0.1/math.E)},
+		{name: "log(-1)", f: Log, input: New(-1, 0.1), err: ErrDomain},
+		{name: "log10(100)", f: Log10, input: New(100, 1), expected: New(2, 0.01/math.Ln10)},
+		{name: "log10(0)", f: Log10, input: New(0, 1), err: ErrDomain},
+		{name: "log2(8)", f: Log2, input: New(8, 0.8), expected: New(3, 0.1/math.Ln2)},
+		{name: "log2 across 0", f: Log2, input: New(1, 2), err: ErrDomain},
+		{name: "log1p(1e-20)", f: Log1p, input: New(1e-20, 1e-21), expected: New(1e-20, 1e-21)},
+		{name: "log1p(-1)", f: Log1p, input: New(-0.5, 0.5), err: ErrDomain},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.f(test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestHypot(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b     Float64
+		expected Float64
+	}{
+		{a: New(3, 0), b: New(4, 0), expected: New(5, 0)},
+		{a: New(3, 0.5), b: New(4, 0), expected: New(5, 0.3)},
+		{a: New(-3, 0.5), b: New(4, 0.5), expected: New(5, 0.7)},
+		{a: New(0, 3), b: New(0, 4), expected: New(0, 5)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("(%v;%v)", test.a, test.b), func(t *testing.T) {
+			if actual := Hypot(test.a, test.b); !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestAbs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    Float64
+		expected Float64
+	}{
+		{input: New(3, 1), expected: New(3, 1)},
+		{input: New(-3, 1), expected: New(3, 1)},
+		{input: New(-1, 1), expected: New(1, 1)},
+		{input: New(1, 3), expected: New(2, 2)},
+		{input: New(-1, 3), expected: New(2, 2)},
+		{input: New(0, 0), expected: New(0, 0)},
+		{input: New(-2, 1).WithDistribution(Uniform), expected: New(2, 1).WithDistribution(Uniform)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v", test.input), func(t *testing.T) {
+			if actual := Abs(test.input); !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestNegReciprocal(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input      Float64
+		neg        Float64
+		reciprocal Float64
+		err        error
+	}{
+		{input: New(2, 0.2), neg: New(-2, 0.2), reciprocal: New(0.5, 0.05)},
+		{input: New(-4, 1), neg: New(4, 1), reciprocal: New(-0.25, 0.0625)},
+		{input: New(0, 1), neg: New(0, 1), err: ErrDivisorContainsZero},
+		{input: New(1, 1), neg: New(-1, 1), err: ErrDivisorContainsZero},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v", test.input), func(t *testing.T) {
+			if actual := Neg(test.input); !cmp.Equal(actual, test.neg, opts...) {
+				t.Errorf("neg: expected: %v, actual: %v", test.neg, actual)
+			}
+			actual, err := Reciprocal(test.input)
+			if !errors.Is(err, test.err) {
+				t.Errorf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !cmp.Equal(actual, test.reciprocal, opts...) {
+				t.Errorf("reciprocal: expected: %v, actual: %v", test.reciprocal, actual)
+			}
+		})
+	}
+}
+
+func TestMaxMin(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b     Float64
+		max, min Float64
+	}{
+		{a: New(1, 1), b: New(5, 1), max: New(5, 1), min: New(1, 1)},
+		{a: New(5, 1), b: New(1, 1), max: New(5, 1), min: New(1, 1)},
+		{a: New(2, 1), b: New(3, 2), max: New(3, 2), min: New(2, 1)},
+		{a: New(2, 3), b: New(2, 1), max: New(3, 2), min: New(1, 2)},
+		{a: New(2, 0), b: New(2, 0), max: New(2, 0), min: New(2, 0)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("(%v;%v)", test.a, test.b), func(t *testing.T) {
+			if actual := Max(test.a, test.b); !cmp.Equal(actual, test.max, opts...) {
+				t.Errorf("max: expected: %v, actual: %v", test.max, actual)
+			}
+			if actual := Min(test.a, test.b); !cmp.Equal(actual, test.min, opts...) {
+				t.Errorf("min: expected: %v, actual: %v", test.min, actual)
+			}
+		})
+	}
+}
+
+func TestClamp(t *testing.T) {
+	t.Parallel()
+	lo, hi := New(0, 0.1), New(10, 0.5)
+	tests := []struct {
+		x        Float64
+		expected Float64
+	}{
+		{x: New(5, 1), expected: New(5, 1)},
+		{x: New(-5, 1), expected: lo},
+		{x: New(15, 1), expected: hi},
+		{x: New(0, 1), expected: New(0.45, 0.55)},
+		{x: New(10, 1), expected: New(9.75, 0.75)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v", test.x), func(t *testing.T) {
+			if actual := Clamp(test.x, lo, hi); !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIntegral(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name      string
+		f         func(Float64) (Float64, bool)
+		input     Float64
+		expected  Float64
+		ambiguous bool
+	}{
+		{name: "floor", f: Floor, input: New(3.9, 0.2), expected: New(3.5, 0.5), ambiguous: true},
+		{name: "floor", f: Floor, input: New(3.5, 0.2), expected: New(3, 0)},
+		{name: "floor", f: Floor, input: New(-0.5, 0.2), expected: New(-1, 0)},
+		{name: "ceil", f: Ceil, input: New(3.9, 0.2), expected: New(4.5, 0.5), ambiguous: true},
+		{name: "ceil", f: Ceil, input: New(3.5, 0.2), expected: New(4, 0)},
+		{name: "round", f: RoundInt, input: New(3.5, 0.2), expected: New(3.5, 0.5), ambiguous: true},
+		{name: "round", f: RoundInt, input: New(3.9, 0.2), expected: New(4, 0)},
+		{name: "round", f: RoundInt, input: New(3, 2), expected: New(3, 2), ambiguous: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v(%v)", test.name, test.input), func(t *testing.T) {
+			actual, ambiguous := test.f(test.input)
+			if !cmp.Equal(actual, test.expected, opts...) || ambiguous != test.ambiguous {
+				t.Errorf("expected: %v, %v, actual: %v, %v", test.expected, test.ambiguous, actual, ambiguous)
+			}
+		})
+	}
+}
+
+func TestMod(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		a, b     Float64
+		expected Float64
+	}{
+		{a: New(7, 0.5), b: New(3, 0), expected: New(1, 0.5)},
+		{a: New(-7, 0.5), b: New(3, 0), expected: New(-1, 0.5)},
+		{a: New(7, 0.5), b: New(3, 0.1), expected: New(1, 0.7)},
+		{a: New(6, 0.5), b: New(3, 0), expected: New(1.5, 1.5)},
+		{a: New(-6, 0.5), b: New(3, 0), expected: New(-1.5, 1.5)},
+		{a: New(0, 0.5), b: New(3, 0), expected: New(0, 0.5)},
+		{a: New(3, 3.5), b: New(3, 0), expected: New(0, 3)},
+		{a: New(7, 0.5), b: New(0, 1), expected: New(0.5, 0.5)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("Mod(%v,%v)", test.a, test.b), func(t *testing.T) {
+			actual := Mod(test.a, test.b)
+			if !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
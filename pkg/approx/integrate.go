@@ -0,0 +1,49 @@
+package approx
+
+import "math"
+
+// Integrate computes the integral of f from a to b, for example the area
+// under a measured curve.  f is evaluated at n+1 equidistant points, with n
+// rounded up to a multiple of 4.
+//
+// The integral is computed with the composite Simpson's rule.  The delta of
+// the result combines the uncertainty of the values of f, weighted by the
+// rule, with an estimate of the truncation error of the rule.  The truncation
+// error is estimated by comparing with the rule over half as many intervals:
+//   err = |S(n) - S(n/2)| / 15
+func Integrate(f func(float64) Float64, a, b float64, n int) Float64 {
+	if n < 4 {
+		n = 4
+	}
+	n = (n + 3) / 4 * 4
+	h := (b - a) / float64(n)
+	var fine, coarse, delta float64
+	for i := 0; i <= n; i++ {
+		y := f(a + float64(i)*h)
+		// Simpson's weights are 1, 4, 2, 4, ..., 2, 4, 1, times h/3.
+		w := 2.0
+		switch {
+		case i == 0 || i == n:
+			w = 1
+		case i%2 == 1:
+			w = 4
+		}
+		fine += w * y.val
+		delta += w * y.delta
+		if i%2 == 0 {
+			// The same weights over every other point, times 2h/3.
+			w := 2.0
+			switch {
+			case i == 0 || i == n:
+				w = 1
+			case i%4 == 2:
+				w = 4
+			}
+			coarse += 2 * w * y.val
+		}
+	}
+	fine *= h / 3
+	coarse *= h / 3
+	delta *= math.Abs(h) / 3
+	return New(fine, delta+math.Abs(fine-coarse)/15)
+}
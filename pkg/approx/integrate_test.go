@@ -0,0 +1,65 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestIntegrate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		f        func(float64) Float64
+		a, b     float64
+		n        int
+		expected Float64
+	}{
+		{
+			name:     "constant",
+			f:        func(float64) Float64 { return New(2, 0.1) },
+			a:        0,
+			b:        3,
+			n:        4,
+			expected: New(6, 0.3),
+		},
+		{
+			name:     "cubic is exact",
+			f:        func(x float64) Float64 { return New(x*x*x, 0) },
+			a:        0,
+			b:        2,
+			n:        1,
+			expected: New(4, 0),
+		},
+		{
+			name:     "reversed",
+			f:        func(x float64) Float64 { return New(x, 0.5) },
+			a:        2,
+			b:        0,
+			n:        8,
+			expected: New(-2, 1),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if actual := Integrate(test.f, test.a, test.b, test.n); !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIntegrateTruncation(t *testing.T) {
+	t.Parallel()
+	sin := func(x float64) Float64 { return New(math.Sin(x), 0) }
+	for _, n := range []int{4, 8, 16, 64} {
+		n := n
+		t.Run(fmt.Sprint(n), func(t *testing.T) {
+			actual := Integrate(sin, 0, math.Pi, n)
+			if err := math.Abs(actual.Value() - 2); err == 0 || err > actual.Delta() {
+				t.Errorf("expected %v to cover the truncation error %v", actual, err)
+			}
+		})
+	}
+}
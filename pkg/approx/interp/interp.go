@@ -0,0 +1,131 @@
+// Package interp interpolates tables of approximate numbers.
+//
+// The interpolated value is a linear combination of the tabulated values, so
+// their uncertainties propagate into it.  Interpolation also adds an error of
+// its own, since the tabulated function is not exactly a polynomial between
+// the points.  This error is estimated by comparing with the interpolation of
+// the next higher order, and added to the delta of the result.
+//
+// Example:
+//
+//     t, _ := interp.NewTable(
+//         []float64{0, 1, 2, 3},
+//         []approx.Float64{
+//             approx.New(0, 0.1), approx.New(1, 0.1),
+//             approx.New(4, 0.1), approx.New(9, 0.1)})
+//     y, _ := t.Linear(1.5) // 2.5±0.35
+package interp
+
+import (
+	"fmt"
+	"math"
+	"sort"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+// Table is a table of the values y of a function at the points x.
+type Table struct {
+	x []float64
+	y []approx.Float64
+}
+
+// NewTable constructs a Table of the values y at the points x.  The points
+// must be strictly increasing.  The arguments are copied.
+func NewTable(x []float64, y []approx.Float64) (Table, error) {
+	if len(x) != len(y) {
+		return Table{}, fmt.Errorf("x and y must have the same length: len(x)=%v, len(y)=%v", len(x), len(y))
+	}
+	for i := 1; i < len(x); i++ {
+		if !(x[i-1] < x[i]) {
+			return Table{}, fmt.Errorf("x must be strictly increasing: x[%v]=%v, x[%v]=%v", i-1, x[i-1], i, x[i])
+		}
+	}
+	return Table{
+		x: append([]float64(nil), x...),
+		y: append([]approx.Float64(nil), y...),
+	}, nil
+}
+
+// Len returns the number of points in t.
+func (t Table) Len() int {
+	return len(t.x)
+}
+
+// Linear interpolates t at x linearly between the two surrounding points.
+// x must be within the range of the points of t.
+func (t Table) Linear(x float64) (approx.Float64, error) {
+	return t.interpolate(x, 1)
+}
+
+// Cubic interpolates t at x with the cubic polynomial through the four
+// surrounding points.  x must be within the range of the points of t, which
+// must have at least four points.
+func (t Table) Cubic(x float64) (approx.Float64, error) {
+	return t.interpolate(x, 3)
+}
+
+// interpolate interpolates t at x with the polynomial of the given order.
+func (t Table) interpolate(x float64, order int) (approx.Float64, error) {
+	n := len(t.x)
+	if n < order+1 {
+		return approx.Float64{}, fmt.Errorf("need at least %v points to interpolate with order %v, have: %v", order+1, order, n)
+	}
+	if !(t.x[0] <= x && x <= t.x[n-1]) {
+		return approx.Float64{}, fmt.Errorf("could not interpolate at %v: outside of [%v, %v]", x, t.x[0], t.x[n-1])
+	}
+	// i is the index of the point at the left of the interval containing x.
+	i := sort.SearchFloat64s(t.x, x) - 1
+	if i < 0 {
+		i = 0
+	}
+	start := clamp(i-(order-1)/2, 0, n-order-1)
+	coefs := lagrange(x, t.x[start:start+order+1])
+	var val, delta float64
+	for j, c := range coefs {
+		val += c * t.y[start+j].Value()
+		delta += math.Abs(c) * t.y[start+j].Delta()
+	}
+	// Estimate the interpolation error from the next higher order, whose
+	// window has one more point, the one nearer to x.
+	if n > order+1 {
+		s := start
+		switch {
+		case start == 0:
+		case start+order+1 == n || x-t.x[start-1] < t.x[start+order+1]-x:
+			s = start - 1
+		}
+		var higher float64
+		for j, c := range lagrange(x, t.x[s:s+order+2]) {
+			higher += c * t.y[s+j].Value()
+		}
+		delta += math.Abs(higher - val)
+	}
+	return approx.New(val, delta), nil
+}
+
+// lagrange returns the coefficients with which the values at the points xs
+// combine into the value of the interpolating polynomial at x.
+func lagrange(x float64, xs []float64) []float64 {
+	c := make([]float64, len(xs))
+	for j := range xs {
+		c[j] = 1
+		for k := range xs {
+			if k != j {
+				c[j] *= (x - xs[k]) / (xs[j] - xs[k])
+			}
+		}
+	}
+	return c
+}
+
+// clamp limits i to the range from lo to hi.
+func clamp(i, lo, hi int) int {
+	if i < lo {
+		return lo
+	}
+	if i > hi {
+		return hi
+	}
+	return i
+}
@@ -0,0 +1,105 @@
+package interp
+
+import (
+	"math"
+	"testing"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+// near returns true if a and b have close values and deltas.
+func near(a, b approx.Float64) bool {
+	return math.Abs(a.Value()-b.Value()) < 1e-12 && math.Abs(a.Delta()-b.Delta()) < 1e-12
+}
+
+func TestNewTable(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		x    []float64
+		y    []approx.Float64
+	}{
+		{name: "length mismatch", x: []float64{0, 1}, y: []approx.Float64{approx.New(0, 0)}},
+		{name: "not increasing", x: []float64{0, 1, 1}, y: make([]approx.Float64, 3)},
+		{name: "NaN", x: []float64{0, math.NaN()}, y: make([]approx.Float64, 2)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if _, err := NewTable(test.x, test.y); err == nil {
+				t.Errorf("expected error")
+			}
+		})
+	}
+}
+
+func TestInterpolate(t *testing.T) {
+	t.Parallel()
+	// Squares.
+	squares, err := NewTable(
+		[]float64{0, 1, 2, 3},
+		[]approx.Float64{approx.New(0, 0.1), approx.New(1, 0.1), approx.New(4, 0.1), approx.New(9, 0.1)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	line, err := NewTable([]float64{0, 1}, []approx.Float64{approx.New(1, 0.2), approx.New(3, 0.4)})
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	tests := []struct {
+		name     string
+		f        func(float64) (approx.Float64, error)
+		x        float64
+		expected approx.Float64
+		err      bool
+	}{
+		{name: "linear", f: squares.Linear, x: 1.5, expected: approx.New(2.5, 0.35)},
+		{name: "linear at point", f: squares.Linear, x: 2, expected: approx.New(4, 0.1)},
+		{name: "linear at start", f: squares.Linear, x: 0, expected: approx.New(0, 0.1)},
+		{name: "linear at end", f: squares.Linear, x: 3, expected: approx.New(9, 0.1)},
+		{name: "linear near start", f: squares.Linear, x: 0.5, expected: approx.New(0.5, 0.1+0.25)},
+		{name: "linear near end", f: squares.Linear, x: 2.5, expected: approx.New(6.5, 0.1+0.25)},
+		{name: "linear two points", f: line.Linear, x: 0.25, expected: approx.New(1.5, 0.25)},
+		{name: "cubic", f: squares.Cubic, x: 1.5, expected: approx.New(2.25, 0.125)},
+		{name: "cubic too few points", f: line.Cubic, x: 0.5, err: true},
+		{name: "out of range", f: squares.Linear, x: 3.5, err: true},
+		{name: "NaN", f: squares.Linear, x: math.NaN(), err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.f(test.x)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if err == nil && !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCubicError(t *testing.T) {
+	t.Parallel()
+	// The interpolation error of a smooth function is estimated.  It is an
+	// estimate, not a bound, but it is of the same order as the actual error.
+	var x []float64
+	var y []approx.Float64
+	for i := 0; i <= 10; i++ {
+		x = append(x, float64(i)/10)
+		y = append(y, approx.New(math.Sin(float64(i)/10), 0))
+	}
+	table, err := NewTable(x, y)
+	if err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	for _, xi := range []float64{0.05, 0.33, 0.51, 0.97} {
+		actual, err := table.Cubic(xi)
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if actual.Delta() == 0 || math.Abs(actual.Value()-math.Sin(xi)) > 3*actual.Delta() {
+			t.Errorf("expected %v to estimate the error from sin(%v)=%v", actual, xi, math.Sin(xi))
+		}
+	}
+}
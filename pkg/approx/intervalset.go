@@ -0,0 +1,158 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+	"sort"
+	"strings"
+)
+
+// IntervalSet is a union of disjoint closed intervals.  Where a Float64
+// describes a single interval, an IntervalSet describes e.g. the set of
+// values that a non-monotone function, such as Mod, Abs or Sin, may take on
+// disjoint pieces of its domain, or the set of values allowed by several
+// specification ranges.
+//
+// The intervals are kept sorted, and the intervals which overlap or touch
+// are merged.  The zero IntervalSet is empty.
+//
+// Example:
+//     s := approx.NewIntervalSet(approx.New(1, 1), approx.New(5, 1), approx.New(2.5, 0.5))
+//     s.String()      // [0, 3] ∪ [4, 6]
+//     s.Contains(3.5) // false
+type IntervalSet struct {
+	spans []span
+}
+
+// span is a closed interval [min, max], with min <= max.
+type span struct {
+	min, max float64
+}
+
+// NewIntervalSet returns the union of the intervals of fs.  Numbers with a
+// NaN value or delta are skipped, as they contain no values.
+func NewIntervalSet(fs ...Float64) IntervalSet {
+	spans := make([]span, 0, len(fs))
+	for _, f := range fs {
+		if min, max := f.Min(), f.Max(); min <= max {
+			spans = append(spans, span{min, max})
+		}
+	}
+	return IntervalSet{spans: merge(spans)}
+}
+
+// merge sorts spans, and merges the spans which overlap or touch, reusing
+// the storage of spans.
+func merge(spans []span) []span {
+	if len(spans) == 0 {
+		return nil
+	}
+	sort.Slice(spans, func(i, j int) bool {
+		return spans[i].min < spans[j].min
+	})
+	r := spans[:1]
+	for _, s := range spans[1:] {
+		last := &r[len(r)-1]
+		if s.min <= last.max {
+			last.max = math.Max(last.max, s.max)
+			continue
+		}
+		r = append(r, s)
+	}
+	return r
+}
+
+// Len returns the number of disjoint intervals in s.
+func (s IntervalSet) Len() int {
+	return len(s.spans)
+}
+
+// IsEmpty returns true if s contains no values.
+func (s IntervalSet) IsEmpty() bool {
+	return len(s.spans) == 0
+}
+
+// Interval returns the bounds of the i-th interval of s, in increasing
+// order.  The bounds of the first and the last interval may be infinite, e.g.
+// in a complement.
+func (s IntervalSet) Interval(i int) (min, max float64) {
+	return s.spans[i].min, s.spans[i].max
+}
+
+// Hull returns the smallest approximate number whose interval contains all of
+// s.  The hull of an empty set is NaN.
+func (s IntervalSet) Hull() Float64 {
+	if len(s.spans) == 0 {
+		return New(math.NaN(), 0)
+	}
+	return fromMinMax(s.spans[0].min, s.spans[len(s.spans)-1].max)
+}
+
+// Contains returns true if x lies within one of the intervals of s, bounds
+// included.
+func (s IntervalSet) Contains(x float64) bool {
+	i := sort.Search(len(s.spans), func(i int) bool {
+		return s.spans[i].max >= x
+	})
+	return i < len(s.spans) && s.spans[i].min <= x
+}
+
+// Union returns the set of values which are in s, or in t.
+func (s IntervalSet) Union(t IntervalSet) IntervalSet {
+	spans := make([]span, 0, len(s.spans)+len(t.spans))
+	spans = append(spans, s.spans...)
+	spans = append(spans, t.spans...)
+	return IntervalSet{spans: merge(spans)}
+}
+
+// Intersect returns the set of values which are both in s and in t.
+func (s IntervalSet) Intersect(t IntervalSet) IntervalSet {
+	var spans []span
+	for i, j := 0, 0; i < len(s.spans) && j < len(t.spans); {
+		a, b := s.spans[i], t.spans[j]
+		if min, max := math.Max(a.min, b.min), math.Min(a.max, b.max); min <= max {
+			spans = append(spans, span{min, max})
+		}
+		if a.max < b.max {
+			i++
+		} else {
+			j++
+		}
+	}
+	return IntervalSet{spans: spans}
+}
+
+// Complement returns the set of values which are not in s, with the bounds of
+// s included.  As the intervals are closed, this is the closure of the
+// complement, e.g. the complement of [1, 2] is [-Inf, 1] ∪ [2, +Inf], and the
+// complement of the single point [1, 1] is [-Inf, +Inf].
+func (s IntervalSet) Complement() IntervalSet {
+	var spans []span
+	lo := math.Inf(-1)
+	for _, t := range s.spans {
+		if lo < t.min {
+			spans = append(spans, span{lo, t.min})
+		}
+		lo = t.max
+	}
+	if hi := math.Inf(1); lo < hi {
+		spans = append(spans, span{lo, hi})
+	}
+	return IntervalSet{spans: merge(spans)}
+}
+
+// String implements Stringer.  The intervals are written as by
+// IntervalString, joined by "∪".  The empty set is written as "∅".
+func (s IntervalSet) String() string {
+	if len(s.spans) == 0 {
+		return "∅"
+	}
+	var b strings.Builder
+	for i, t := range s.spans {
+		if i > 0 {
+			b.WriteString(" ∪ ")
+		}
+		fmt.Fprintf(&b, "[%v, %v]", t.min, t.max)
+	}
+	return b.String()
+}
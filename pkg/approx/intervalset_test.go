@@ -0,0 +1,108 @@
+package approx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestIntervalSet(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		fs       []Float64
+		expected string
+	}{
+		{name: "empty", expected: "∅"},
+		{name: "single", fs: []Float64{New(1, 1)}, expected: "[0, 2]"},
+		{name: "disjoint", fs: []Float64{New(5, 1), New(1, 1)}, expected: "[0, 2] ∪ [4, 6]"},
+		{name: "overlapping", fs: []Float64{New(1, 1), New(5, 1), New(2.5, 0.5)}, expected: "[0, 3] ∪ [4, 6]"},
+		{name: "touching", fs: []Float64{New(1, 1), New(3, 1)}, expected: "[0, 4]"},
+		{name: "nested", fs: []Float64{New(0, 10), New(1, 1)}, expected: "[-10, 10]"},
+		{name: "point", fs: []Float64{New(1, 0)}, expected: "[1, 1]"},
+		{name: "NaN", fs: []Float64{New(math.NaN(), 1), New(1, 1)}, expected: "[0, 2]"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := NewIntervalSet(test.fs...).String(); actual != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIntervalSetContains(t *testing.T) {
+	t.Parallel()
+	s := NewIntervalSet(New(1, 1), New(5, 1), New(10, 0))
+	tests := []struct {
+		x        float64
+		expected bool
+	}{
+		{x: -1, expected: false},
+		{x: 0, expected: true},
+		{x: 1.5, expected: true},
+		{x: 2, expected: true},
+		{x: 3, expected: false},
+		{x: 6, expected: true},
+		{x: 10, expected: true},
+		{x: 10.5, expected: false},
+		{x: math.NaN(), expected: false},
+	}
+	for _, test := range tests {
+		if actual := s.Contains(test.x); actual != test.expected {
+			t.Errorf("%v.Contains(%v): expected: %v, actual: %v", s, test.x, test.expected, actual)
+		}
+	}
+	if (IntervalSet{}).Contains(0) {
+		t.Errorf("the empty set contains 0")
+	}
+}
+
+func TestIntervalSetOps(t *testing.T) {
+	t.Parallel()
+	a := NewIntervalSet(New(1, 1), New(5, 1))
+	b := NewIntervalSet(New(2, 0.5), New(6, 2))
+	tests := []struct {
+		name     string
+		s        IntervalSet
+		expected string
+	}{
+		{name: "union", s: a.Union(b), expected: "[0, 2.5] ∪ [4, 8]"},
+		{name: "union empty", s: a.Union(IntervalSet{}), expected: "[0, 2] ∪ [4, 6]"},
+		{name: "intersect", s: a.Intersect(b), expected: "[1.5, 2] ∪ [4, 6]"},
+		{name: "intersect touching", s: a.Intersect(NewIntervalSet(New(3, 1))), expected: "[2, 2] ∪ [4, 4]"},
+		{name: "intersect disjoint", s: a.Intersect(NewIntervalSet(New(3, 0.5))), expected: "∅"},
+		{name: "complement", s: a.Complement(), expected: "[-Inf, 0] ∪ [2, 4] ∪ [6, +Inf]"},
+		{name: "complement empty", s: IntervalSet{}.Complement(), expected: "[-Inf, +Inf]"},
+		{name: "complement all", s: IntervalSet{}.Complement().Complement(), expected: "∅"},
+		{name: "complement twice", s: a.Complement().Complement(), expected: "[0, 2] ∪ [4, 6]"},
+		{name: "complement point", s: NewIntervalSet(New(1, 0)).Complement(), expected: "[-Inf, +Inf]"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := test.s.String(); actual != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestIntervalSetAccessors(t *testing.T) {
+	t.Parallel()
+	s := NewIntervalSet(New(5, 1), New(1, 1))
+	if s.Len() != 2 || s.IsEmpty() {
+		t.Errorf("expected 2 intervals, actual: %v", s)
+	}
+	if min, max := s.Interval(1); min != 4 || max != 6 {
+		t.Errorf("expected: [4, 6], actual: [%v, %v]", min, max)
+	}
+	if actual, expected := s.Hull(), New(3, 3); actual != expected {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+	if empty := (IntervalSet{}); !empty.IsEmpty() || !empty.Hull().IsNaN() {
+		t.Errorf("expected empty, actual: %v, %v", empty, empty.Hull())
+	}
+}
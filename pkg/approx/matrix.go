@@ -0,0 +1,291 @@
+package approx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strings"
+)
+
+// ErrSingularMatrix is returned when solving a linear system whose matrix is
+// singular.
+var ErrSingularMatrix = errors.New("matrix is singular")
+
+// Matrix is a dense matrix of approximate numbers, for small linear models
+// with uncertain coefficients.
+//
+// The elements are treated as independent.  Operations propagate the
+// uncertainties of the elements with the first order Taylor expansion, taking
+// into account that elements may appear several times in a formula, e.g. in
+// the determinant.
+type Matrix struct {
+	rows, cols int
+	// elems is stored row-major.
+	elems []Float64
+}
+
+// NewMatrix constructs a Matrix from its rows.  All rows must have the same
+// length.
+func NewMatrix(rows [][]Float64) (Matrix, error) {
+	m := Matrix{rows: len(rows)}
+	if len(rows) > 0 {
+		m.cols = len(rows[0])
+	}
+	m.elems = make([]Float64, 0, m.rows*m.cols)
+	for i, row := range rows {
+		if len(row) != m.cols {
+			return Matrix{}, fmt.Errorf("matrix row %v must have %v columns, has: %v", i, m.cols, len(row))
+		}
+		m.elems = append(m.elems, row...)
+	}
+	return m, nil
+}
+
+// Dims returns the number of rows and columns of m.
+func (m Matrix) Dims() (rows, cols int) {
+	return m.rows, m.cols
+}
+
+// At returns the element of m in row i and column j.
+func (m Matrix) At(i, j int) Float64 {
+	return m.elems[i*m.cols+j]
+}
+
+// String implements Stringer.
+func (m Matrix) String() string {
+	var b strings.Builder
+	b.WriteString("[")
+	for i := 0; i < m.rows; i++ {
+		if i > 0 {
+			b.WriteString(" ")
+		}
+		fmt.Fprintf(&b, "%v", m.elems[i*m.cols:(i+1)*m.cols])
+	}
+	b.WriteString("]")
+	return b.String()
+}
+
+// Add computes m+n.  The matrices must have the same dimensions.
+func (m Matrix) Add(n Matrix) (Matrix, error) {
+	if m.rows != n.rows || m.cols != n.cols {
+		return Matrix{}, fmt.Errorf("could not add %vx%v matrix to %vx%v matrix", n.rows, n.cols, m.rows, m.cols)
+	}
+	r := Matrix{rows: m.rows, cols: m.cols, elems: make([]Float64, len(m.elems))}
+	for i := range m.elems {
+		r.elems[i] = Add(m.elems[i], n.elems[i])
+	}
+	return r, nil
+}
+
+// Mul computes the matrix product m*n.  The number of columns of m must be
+// the same as the number of rows of n.
+func (m Matrix) Mul(n Matrix) (Matrix, error) {
+	if m.cols != n.rows {
+		return Matrix{}, fmt.Errorf("could not multiply %vx%v matrix by %vx%v matrix", m.rows, m.cols, n.rows, n.cols)
+	}
+	r := Matrix{rows: m.rows, cols: n.cols, elems: make([]Float64, m.rows*n.cols)}
+	col := make([]Float64, n.rows)
+	for j := 0; j < n.cols; j++ {
+		for k := range col {
+			col[k] = n.At(k, j)
+		}
+		for i := 0; i < m.rows; i++ {
+			r.elems[i*r.cols+j] = dot(m.elems[i*m.cols:(i+1)*m.cols], col)
+		}
+	}
+	return r, nil
+}
+
+// Transpose returns the transpose of m.
+func (m Matrix) Transpose() Matrix {
+	r := Matrix{rows: m.cols, cols: m.rows, elems: make([]Float64, len(m.elems))}
+	for i := 0; i < m.rows; i++ {
+		for j := 0; j < m.cols; j++ {
+			r.elems[j*r.cols+i] = m.At(i, j)
+		}
+	}
+	return r
+}
+
+// Det computes the determinant of the square matrix m.
+//
+// Based on first order Taylor expansion around the values of m:
+//   d(det) = sum(|C_ij| * da_ij)
+// where C_ij is the cofactor of the element a_ij, which is the partial
+// derivative of the determinant by a_ij.
+func (m Matrix) Det() (Float64, error) {
+	if m.rows != m.cols {
+		return Float64{}, fmt.Errorf("could not compute determinant of %vx%v matrix: not square", m.rows, m.cols)
+	}
+	n := m.rows
+	vals := m.values()
+	val := det(vals, n)
+	minor := make([]float64, 0, (n-1)*(n-1))
+	r := New(val, 0)
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			e := m.At(i, j)
+			if e.delta == 0 {
+				continue
+			}
+			minor = minor[:0]
+			for k := 0; k < n; k++ {
+				for l := 0; l < n; l++ {
+					if k != i && l != j {
+						minor = append(minor, vals[k*n+l])
+					}
+				}
+			}
+			cofactor := det(minor, n-1)
+			r = New(val, r.delta+math.Abs(cofactor)*e.delta).WithDistribution(combineDist(r, e))
+		}
+	}
+	return r, nil
+}
+
+// Solve solves the linear system m*x = b for x, where m is a square matrix.
+// Returns an error wrapping ErrSingularMatrix if the values of m form a
+// singular matrix.
+//
+// Based on first order Taylor expansion around the values of m and b:
+//   dx_i = sum_k(|inv_ik| * db_k) + sum_jk(|inv_ij * x_k| * da_jk)
+// where inv is the inverse of the values of m.
+func (m Matrix) Solve(b []Float64) ([]Float64, error) {
+	n := m.rows
+	if m.cols != n {
+		return nil, fmt.Errorf("could not solve system with %vx%v matrix: not square", m.rows, m.cols)
+	}
+	if len(b) != n {
+		return nil, fmt.Errorf("could not solve system with %vx%v matrix: right hand side has length: %v", n, n, len(b))
+	}
+	inv, err := inverse(m.values(), n)
+	if err != nil {
+		return nil, fmt.Errorf("could not solve system with matrix %v: %w", m, err)
+	}
+	vals := make([]float64, n)
+	for i := range vals {
+		for k := 0; k < n; k++ {
+			vals[i] += inv[i*n+k] * b[k].val
+		}
+	}
+	x := make([]Float64, n)
+	for i := range x {
+		r := New(vals[i], 0)
+		for k := 0; k < n; k++ {
+			r = New(vals[i], r.delta+math.Abs(inv[i*n+k])*b[k].delta).WithDistribution(combineDist(r, b[k]))
+			for j := 0; j < n; j++ {
+				e := m.At(j, k)
+				r = New(vals[i], r.delta+math.Abs(inv[i*n+j]*vals[k])*e.delta).WithDistribution(combineDist(r, e))
+			}
+		}
+		x[i] = r
+	}
+	return x, nil
+}
+
+// SolveLinear solves the linear system A*x = b for x, propagating the
+// uncertainties of the coefficients in A and of the right hand side b into
+// the solution, see Matrix.Solve.
+//
+// Example:
+//     // Nodal analysis of a voltage divider: a 10V±1% source with an internal
+//     // conductance of 1S, loaded by a 1S±5% conductance.  The node equation
+//     // is (G1+G2)*v = G1*V.
+//     v, _ := approx.SolveLinear(
+//         [][]approx.Float64{{approx.New(2, 0.05)}},
+//         []approx.Float64{approx.New(10, 0.1)})
+//     // v[0] is 5±0.175
+func SolveLinear(A [][]Float64, b []Float64) ([]Float64, error) {
+	m, err := NewMatrix(A)
+	if err != nil {
+		return nil, fmt.Errorf("could not solve linear system: %w", err)
+	}
+	return m.Solve(b)
+}
+
+// values returns the values of the elements of m, row-major.
+func (m Matrix) values() []float64 {
+	vals := make([]float64, len(m.elems))
+	for i, e := range m.elems {
+		vals[i] = e.val
+	}
+	return vals
+}
+
+// det computes the determinant of the n by n row-major matrix a, by Gaussian
+// elimination with partial pivoting.
+func det(a []float64, n int) float64 {
+	a = append([]float64(nil), a...)
+	d := 1.0
+	for c := 0; c < n; c++ {
+		p := pivot(a, n, c)
+		if a[p*n+c] == 0 {
+			return 0
+		}
+		if p != c {
+			swapRows(a, n, p, c)
+			d = -d
+		}
+		d *= a[c*n+c]
+		for r := c + 1; r < n; r++ {
+			f := a[r*n+c] / a[c*n+c]
+			for k := c; k < n; k++ {
+				a[r*n+k] -= f * a[c*n+k]
+			}
+		}
+	}
+	return d
+}
+
+// inverse computes the inverse of the n by n row-major matrix a, by
+// Gauss-Jordan elimination with partial pivoting.
+func inverse(a []float64, n int) ([]float64, error) {
+	a = append([]float64(nil), a...)
+	inv := make([]float64, n*n)
+	for i := 0; i < n; i++ {
+		inv[i*n+i] = 1
+	}
+	for c := 0; c < n; c++ {
+		p := pivot(a, n, c)
+		if a[p*n+c] == 0 {
+			return nil, ErrSingularMatrix
+		}
+		swapRows(a, n, p, c)
+		swapRows(inv, n, p, c)
+		f := a[c*n+c]
+		for k := 0; k < n; k++ {
+			a[c*n+k] /= f
+			inv[c*n+k] /= f
+		}
+		for r := 0; r < n; r++ {
+			if r == c {
+				continue
+			}
+			f := a[r*n+c]
+			for k := 0; k < n; k++ {
+				a[r*n+k] -= f * a[c*n+k]
+				inv[r*n+k] -= f * inv[c*n+k]
+			}
+		}
+	}
+	return inv, nil
+}
+
+// pivot returns the row at or below row c with the largest element in
+// column c.
+func pivot(a []float64, n, c int) int {
+	p := c
+	for r := c + 1; r < n; r++ {
+		if math.Abs(a[r*n+c]) > math.Abs(a[p*n+c]) {
+			p = r
+		}
+	}
+	return p
+}
+
+// swapRows swaps the rows i and j of the row-major matrix a with n columns.
+func swapRows(a []float64, n, i, j int) {
+	for k := 0; k < n; k++ {
+		a[i*n+k], a[j*n+k] = a[j*n+k], a[i*n+k]
+	}
+}
@@ -0,0 +1,193 @@
+package approx
+
+import (
+	"errors"
+	"testing"
+)
+
+func mustMatrix(m Matrix, err error) Matrix {
+	if err != nil {
+		panic(err)
+	}
+	return m
+}
+
+func TestNewMatrix(t *testing.T) {
+	t.Parallel()
+	if _, err := NewMatrix([][]Float64{{New(1, 0)}, {New(1, 0), New(2, 0)}}); err == nil {
+		t.Errorf("expected error for ragged rows")
+	}
+	m := mustMatrix(NewMatrix([][]Float64{{New(1, 0), New(2, 0.1), New(3, 0)}, {New(4, 0), New(5, 0), New(6, 0.5)}}))
+	if rows, cols := m.Dims(); rows != 2 || cols != 3 {
+		t.Errorf("expected 2x3, actual: %vx%v", rows, cols)
+	}
+	if actual, expected := m.String(), "[[1±0 2±0.1 3±0] [4±0 5±0 6±0.5]]"; actual != expected {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+	if actual, expected := m.Transpose().String(), "[[1±0 4±0] [2±0.1 5±0] [3±0 6±0.5]]"; actual != expected {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestMatrixOps(t *testing.T) {
+	t.Parallel()
+	a := mustMatrix(NewMatrix([][]Float64{{New(2, 0.1), New(1, 0)}, {New(1, 0), New(3, 0.2)}}))
+	b := mustMatrix(NewMatrix([][]Float64{{New(1, 0.5), New(0, 0)}, {New(0, 0), New(1, 0)}}))
+	tests := []struct {
+		name     string
+		actual   Matrix
+		expected string
+	}{
+		{name: "Add", actual: mustMatrix(a.Add(b)), expected: "[[3±0.6 1±0] [1±0 4±0.2]]"},
+		{name: "Mul", actual: mustMatrix(a.Mul(b)), expected: "[[2±1.1 1±0] [1±0.5 3±0.2]]"},
+		{name: "Mul by column", actual: mustMatrix(a.Mul(b.Transpose())), expected: "[[2±1.1 1±0] [1±0.5 3±0.2]]"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if actual := test.actual.String(); actual != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+	row := mustMatrix(NewMatrix([][]Float64{{New(1, 0), New(2, 0)}}))
+	if _, err := a.Add(row); err == nil {
+		t.Errorf("expected error adding %v to %v", row, a)
+	}
+	if _, err := row.Transpose().Mul(a); err == nil {
+		t.Errorf("expected error multiplying %v by %v", row.Transpose(), a)
+	}
+}
+
+func TestDet(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		m        Matrix
+		expected Float64
+	}{
+		{
+			name:     "2x2",
+			m:        mustMatrix(NewMatrix([][]Float64{{New(2, 0.1), New(1, 0)}, {New(1, 0), New(3, 0.2)}})),
+			expected: New(5, 0.7),
+		},
+		{
+			name:     "1x1",
+			m:        mustMatrix(NewMatrix([][]Float64{{New(-2, 0.1)}})),
+			expected: New(-2, 0.1),
+		},
+		{
+			name: "3x3",
+			m: mustMatrix(NewMatrix([][]Float64{
+				{New(0, 0), New(1, 0.1), New(0, 0)},
+				{New(1, 0), New(0, 0), New(0, 0)},
+				{New(0, 0), New(0, 0), New(2, 0.1)},
+			})),
+			expected: New(-2, 0.3),
+		},
+		{
+			name:     "singular",
+			m:        mustMatrix(NewMatrix([][]Float64{{New(1, 0.1), New(2, 0)}, {New(2, 0), New(4, 0)}})),
+			expected: New(0, 0.4),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.m.Det()
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+	if _, err := mustMatrix(NewMatrix([][]Float64{{New(1, 0), New(2, 0)}})).Det(); err == nil {
+		t.Errorf("expected error for non-square matrix")
+	}
+}
+
+func TestSolve(t *testing.T) {
+	t.Parallel()
+	a := mustMatrix(NewMatrix([][]Float64{{New(2, 0.1), New(1, 0)}, {New(1, 0), New(3, 0.2)}}))
+	tests := []struct {
+		name     string
+		b        []Float64
+		expected []Float64
+	}{
+		{name: "exact rhs", b: []Float64{New(3, 0), New(4, 0)}, expected: []Float64{New(1, 0.1), New(1, 0.1)}},
+		{name: "uncertain rhs", b: []Float64{New(3, 0.5), New(4, 0)}, expected: []Float64{New(1, 0.4), New(1, 0.2)}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := a.Solve(test.b)
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			for i := range actual {
+				if !near(actual[i], test.expected[i]) {
+					t.Errorf("expected: %v, actual: %v", test.expected, actual)
+				}
+			}
+		})
+	}
+	singular := mustMatrix(NewMatrix([][]Float64{{New(1, 0.1), New(2, 0)}, {New(2, 0), New(4, 0)}}))
+	if _, err := singular.Solve([]Float64{New(1, 0), New(1, 0)}); !errors.Is(err, ErrSingularMatrix) {
+		t.Errorf("expected error: %v, actual: %v", ErrSingularMatrix, err)
+	}
+	if _, err := a.Solve([]Float64{New(1, 0)}); err == nil {
+		t.Errorf("expected error for mismatched right hand side")
+	}
+}
+
+func TestSolveLinear(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		A        [][]Float64
+		b        []Float64
+		expected []Float64
+		err      bool
+	}{
+		{
+			name:     "divider",
+			A:        [][]Float64{{New(2, 0.05)}},
+			b:        []Float64{New(10, 0.1)},
+			expected: []Float64{New(5, 0.175)},
+		},
+		{
+			name:     "2x2",
+			A:        [][]Float64{{New(2, 0.1), New(1, 0)}, {New(1, 0), New(3, 0.2)}},
+			b:        []Float64{New(3, 0), New(4, 0)},
+			expected: []Float64{New(1, 0.1), New(1, 0.1)},
+		},
+		{
+			name: "ragged",
+			A:    [][]Float64{{New(2, 0.1), New(1, 0)}, {New(1, 0)}},
+			b:    []Float64{New(3, 0), New(4, 0)},
+			err:  true,
+		},
+		{
+			name: "singular",
+			A:    [][]Float64{{New(0, 0.1)}},
+			b:    []Float64{New(3, 0)},
+			err:  true,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := SolveLinear(test.A, test.b)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			for i := range actual {
+				if !near(actual[i], test.expected[i]) {
+					t.Errorf("expected: %v, actual: %v", test.expected, actual)
+				}
+			}
+		})
+	}
+}
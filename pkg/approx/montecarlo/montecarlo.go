@@ -0,0 +1,132 @@
+// Package montecarlo propagates uncertainty through arbitrary functions by
+// random sampling.
+//
+// The first order Taylor expansion used by package approx breaks down for
+// strongly nonlinear functions, and for inputs whose uncertainty is large
+// compared to the curvature of the function.  Monte Carlo propagation makes no
+// such assumption: each input is treated as a probability distribution, the
+// function is evaluated for many random draws of the inputs, and the
+// resulting sample is summarized.
+//
+// Example:
+//
+//     r := montecarlo.Run(func(x []float64) float64 {
+//         return x[0] * math.Sin(x[1])
+//     }, 100000, nil, a, b)
+//     fmt.Println(r.Float64(), r.Percentile(2.5), r.Percentile(97.5))
+package montecarlo
+
+import (
+	"math"
+	"math/rand"
+	"sort"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+// Result is the sample of function values produced by Run.
+type Result struct {
+	// samples is sorted in ascending order.
+	samples []float64
+}
+
+// Run evaluates f n times, each time on a random draw of inputs, and returns
+// the obtained sample.  Each input is drawn from its approx.Distribution,
+// centered at its value.  Inputs with an unspecified distribution are drawn
+// from a normal distribution whose standard deviation is the delta.
+//
+// If r is nil, the random numbers are obtained from the default source of
+// the math/rand package.
+func Run(f func([]float64) float64, n int, r *rand.Rand, inputs ...approx.Float64) Result {
+	if r == nil {
+		r = rand.New(globalSource{})
+	}
+	x := make([]float64, len(inputs))
+	samples := make([]float64, n)
+	for i := range samples {
+		for j, in := range inputs {
+			x[j] = in.Value() + in.Delta()*draw(r, in.Distribution())
+		}
+		samples[i] = f(x)
+	}
+	sort.Float64s(samples)
+	return Result{samples: samples}
+}
+
+// draw returns a random deviation from the center of the distribution d, in
+// units of delta.
+func draw(r *rand.Rand, d approx.Distribution) float64 {
+	switch d {
+	case approx.Uniform:
+		return 2*r.Float64() - 1
+	case approx.Triangular:
+		return r.Float64() + r.Float64() - 1
+	default:
+		return r.NormFloat64()
+	}
+}
+
+// globalSource is a rand.Source which draws from the default source of the
+// math/rand package.
+type globalSource struct{}
+
+func (globalSource) Int63() int64 { return rand.Int63() }
+func (globalSource) Seed(int64)   {}
+
+// Len returns the number of samples in r.
+func (r Result) Len() int {
+	return len(r.samples)
+}
+
+// Mean returns the sample mean of r.
+func (r Result) Mean() float64 {
+	var sum float64
+	for _, s := range r.samples {
+		sum += s
+	}
+	return sum / float64(len(r.samples))
+}
+
+// StdDev returns the sample standard deviation of r.
+func (r Result) StdDev() float64 {
+	mean := r.Mean()
+	var sum float64
+	for _, s := range r.samples {
+		sum += (s - mean) * (s - mean)
+	}
+	return math.Sqrt(sum / float64(len(r.samples)-1))
+}
+
+// Float64 summarizes r as its mean, plus or minus its standard deviation.
+func (r Result) Float64() approx.Float64 {
+	return approx.New(r.Mean(), r.StdDev())
+}
+
+// Percentile returns the p-th percentile of r, for p between 0 and 100.
+// Values between the samples are linearly interpolated.
+func (r Result) Percentile(p float64) float64 {
+	n := len(r.samples)
+	if n == 0 {
+		return math.NaN()
+	}
+	pos := p / 100 * float64(n-1)
+	if pos <= 0 {
+		return r.samples[0]
+	}
+	if pos >= float64(n-1) {
+		return r.samples[n-1]
+	}
+	i := int(pos)
+	frac := pos - float64(i)
+	return r.samples[i] + frac*(r.samples[i+1]-r.samples[i])
+}
+
+// Coverage returns the interval which contains the fraction level of the
+// samples, for level between 0 and 1, leaving out equal fractions of the
+// samples on both ends.  For example, Coverage(0.95) spans from the 2.5th to
+// the 97.5th percentile.
+func (r Result) Coverage(level float64) approx.Float64 {
+	tail := (1 - level) / 2 * 100
+	c, _ := approx.NewMinMax(r.Percentile(tail), r.Percentile(100-tail))
+	return c
+}
@@ -0,0 +1,76 @@
+package montecarlo
+
+import (
+	"math"
+	"math/rand"
+	"testing"
+
+	"github.com/filmil/approx/pkg/approx"
+)
+
+func TestRun(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		f        func([]float64) float64
+		inputs   []approx.Float64
+		expected approx.Float64
+	}{
+		{
+			name:     "sum",
+			f:        func(x []float64) float64 { return x[0] + x[1] },
+			inputs:   []approx.Float64{approx.New(1, 3), approx.New(2, 4)},
+			expected: approx.New(3, 5),
+		},
+		{
+			name:     "square",
+			f:        func(x []float64) float64 { return x[0] * x[0] },
+			inputs:   []approx.Float64{approx.New(0, 1)},
+			expected: approx.New(1, math.Sqrt2),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			r := Run(test.f, 100000, rand.New(rand.NewSource(42)), test.inputs...)
+			actual := r.Float64()
+			if math.Abs(actual.Value()-test.expected.Value()) > 0.05 ||
+				math.Abs(actual.Delta()-test.expected.Delta()) > 0.05 {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPercentile(t *testing.T) {
+	t.Parallel()
+	r := Result{samples: []float64{1, 2, 3, 4, 5}}
+	tests := []struct {
+		p, expected float64
+	}{
+		{p: 0, expected: 1},
+		{p: 50, expected: 3},
+		{p: 62.5, expected: 3.5},
+		{p: 100, expected: 5},
+	}
+	for _, test := range tests {
+		if actual := r.Percentile(test.p); actual != test.expected {
+			t.Errorf("Percentile(%v): expected: %v, actual: %v", test.p, test.expected, actual)
+		}
+	}
+	if actual, expected := r.Coverage(0.5), approx.New(3, 1); actual != expected {
+		t.Errorf("Coverage(0.5): expected: %v, actual: %v", expected, actual)
+	}
+}
+
+func TestRunDistribution(t *testing.T) {
+	t.Parallel()
+	in := approx.New(0, 1).WithDistribution(approx.Uniform)
+	r := Run(func(x []float64) float64 { return x[0] }, 100000, rand.New(rand.NewSource(42)), in)
+	if r.Percentile(0) < -1 || r.Percentile(100) > 1 {
+		t.Errorf("sample outside of support: [%v, %v]", r.Percentile(0), r.Percentile(100))
+	}
+	if math.Abs(r.StdDev()-in.StdDev()) > 0.01 {
+		t.Errorf("expected stddev: %v, actual: %v", in.StdDev(), r.StdDev())
+	}
+}
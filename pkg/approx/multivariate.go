@@ -0,0 +1,123 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+)
+
+// Multivariate is a vector of values whose uncertainties are described by a
+// covariance matrix.
+//
+// This is what you typically get out of a fit, where the fitted parameters
+// are correlated.  Treating such parameters as independent Float64s would
+// mis-state the uncertainty of anything computed from them.
+type Multivariate struct {
+	vals []float64
+	// cov is the covariance matrix, stored row-major.
+	cov []float64
+}
+
+// NewMultivariate constructs a Multivariate from values vals and the
+// covariance matrix cov.  cov must be a symmetric len(vals) by len(vals)
+// matrix with a nonnegative diagonal.
+func NewMultivariate(vals []float64, cov [][]float64) (Multivariate, error) {
+	n := len(vals)
+	if len(cov) != n {
+		return Multivariate{}, fmt.Errorf("covariance matrix must have %v rows, has: %v", n, len(cov))
+	}
+	m := Multivariate{
+		vals: append([]float64(nil), vals...),
+		cov:  make([]float64, 0, n*n),
+	}
+	for i, row := range cov {
+		if len(row) != n {
+			return Multivariate{}, fmt.Errorf("covariance matrix row %v must have %v columns, has: %v", i, n, len(row))
+		}
+		if row[i] < 0 {
+			return Multivariate{}, fmt.Errorf("variance must be nonnegative: cov[%v][%v]=%v", i, i, row[i])
+		}
+		for j := 0; j < i; j++ {
+			if row[j] != cov[j][i] {
+				return Multivariate{}, fmt.Errorf("covariance matrix must be symmetric: cov[%v][%v]=%v, cov[%v][%v]=%v",
+					i, j, row[j], j, i, cov[j][i])
+			}
+		}
+		m.cov = append(m.cov, row...)
+	}
+	return m, nil
+}
+
+// Independent constructs a Multivariate from independent approximate numbers.
+// The covariance matrix is diagonal, with the variances computed from the
+// standard uncertainties of xs.
+func Independent(xs ...Float64) Multivariate {
+	n := len(xs)
+	m := Multivariate{
+		vals: make([]float64, n),
+		cov:  make([]float64, n*n),
+	}
+	for i, x := range xs {
+		m.vals[i] = x.val
+		m.cov[i*n+i] = x.StdDev() * x.StdDev()
+	}
+	return m
+}
+
+// Len returns the number of values in m.
+func (m Multivariate) Len() int {
+	return len(m.vals)
+}
+
+// Value returns the i-th value of m.
+func (m Multivariate) Value(i int) float64 {
+	return m.vals[i]
+}
+
+// Covariance returns the covariance of the i-th and the j-th value of m.
+func (m Multivariate) Covariance(i, j int) float64 {
+	return m.cov[i*len(m.vals)+j]
+}
+
+// At returns the i-th value of m, with its standard deviation as the delta.
+// The correlations with the other values are lost.
+func (m Multivariate) At(i int) Float64 {
+	return New(m.vals[i], math.Sqrt(m.Covariance(i, i)))
+}
+
+// Propagate computes f at the values of m, with the uncertainty propagated
+// from the covariance matrix of m.
+//
+// Based on the first order Taylor expansion of f around the values of m:
+//   var(f) = g^T * cov * g
+// where g is the gradient of f, which is computed numerically.
+func (m Multivariate) Propagate(f func([]float64) float64) Float64 {
+	n := len(m.vals)
+	x := append([]float64(nil), m.vals...)
+	g := make([]float64, n)
+	for i := range x {
+		h := step(x[i])
+		x[i] = m.vals[i] + h
+		fmax := f(x)
+		x[i] = m.vals[i] - h
+		fmin := f(x)
+		x[i] = m.vals[i]
+		g[i] = (fmax - fmin) / (2 * h)
+	}
+	var variance float64
+	for i := 0; i < n; i++ {
+		for j := 0; j < n; j++ {
+			variance += g[i] * m.cov[i*n+j] * g[j]
+		}
+	}
+	return New(f(x), math.Sqrt(math.Max(variance, 0)))
+}
+
+// step returns the step size to use for the central difference numeric
+// derivative around x.  The step size balances the truncation error of the
+// central difference against the rounding error of float64 arithmetic.
+func step(x float64) float64 {
+	return math.Cbrt(epsilon) * math.Max(1, math.Abs(x))
+}
+
+// epsilon is the machine epsilon for float64.
+const epsilon = 2.220446049250313e-16
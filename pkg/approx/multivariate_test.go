@@ -0,0 +1,106 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestNewMultivariate(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name string
+		vals []float64
+		cov  [][]float64
+		err  error
+	}{
+		{
+			name: "ok",
+			vals: []float64{1, 2},
+			cov:  [][]float64{{1, 0.5}, {0.5, 4}},
+		},
+		{
+			name: "rows",
+			vals: []float64{1, 2},
+			cov:  [][]float64{{1, 0.5}},
+			err:  fmt.Errorf("covariance matrix must have 2 rows, has: 1"),
+		},
+		{
+			name: "columns",
+			vals: []float64{1, 2},
+			cov:  [][]float64{{1, 0.5}, {0.5}},
+			err:  fmt.Errorf("covariance matrix row 1 must have 2 columns, has: 1"),
+		},
+		{
+			name: "asymmetric",
+			vals: []float64{1, 2},
+			cov:  [][]float64{{1, 0.5}, {0.4, 4}},
+			err:  fmt.Errorf("covariance matrix must be symmetric: cov[1][0]=0.4, cov[0][1]=0.5"),
+		},
+		{
+			name: "negative variance",
+			vals: []float64{1, 2},
+			cov:  [][]float64{{1, 0}, {0, -4}},
+			err:  fmt.Errorf("variance must be nonnegative: cov[1][1]=-4"),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			_, err := NewMultivariate(test.vals, test.cov)
+			if fmt.Sprint(err) != fmt.Sprint(test.err) {
+				t.Errorf("expected error: %v, actual: %v", test.err, err)
+			}
+		})
+	}
+}
+
+func TestPropagate(t *testing.T) {
+	t.Parallel()
+	sum := func(x []float64) float64 { return x[0] + x[1] }
+	diff := func(x []float64) float64 { return x[0] - x[1] }
+	tests := []struct {
+		name     string
+		m        Multivariate
+		f        func([]float64) float64
+		expected Float64
+	}{
+		{
+			name:     "independent sum",
+			m:        Independent(New(1, 3), New(2, 4)),
+			f:        sum,
+			expected: New(3, 5),
+		},
+		{
+			name: "correlated sum",
+			m: mustMultivariate(NewMultivariate([]float64{1, 2},
+				[][]float64{{9, 12}, {12, 16}})),
+			f:        sum,
+			expected: New(3, 7),
+		},
+		{
+			name: "correlated difference",
+			m: mustMultivariate(NewMultivariate([]float64{1, 2},
+				[][]float64{{9, 12}, {12, 16}})),
+			f:        diff,
+			expected: New(-1, 1),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.m.Propagate(test.f)
+			if actual.Value() != test.expected.Value() ||
+				math.Abs(actual.Delta()-test.expected.Delta()) > 1e-9 {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func mustMultivariate(m Multivariate, err error) Multivariate {
+	if err != nil {
+		panic(fmt.Sprintf("unexpected error: %v", err))
+	}
+	return m
+}
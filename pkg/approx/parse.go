@@ -0,0 +1,481 @@
+package approx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"strconv"
+	"strings"
+	"unicode"
+)
+
+// ErrSyntax is the kind of a ParseError in the structure of an approximate
+// number, e.g. a second ±.  Eval also returns it for expressions which can
+// not be parsed.
+var ErrSyntax = errors.New("syntax error")
+
+// ErrBadValue is the kind of a ParseError in the value of a number.
+var ErrBadValue = errors.New("invalid value")
+
+// ErrBadDelta is the kind of a ParseError in the delta of a number.
+var ErrBadDelta = errors.New("invalid delta")
+
+// ParseError is the error returned by Parse.  It tells the kind of the error,
+// and where in the input it was found, so that callers can report it
+// precisely:
+//
+//     _, err := approx.Parse("4.2±x")
+//     var e *approx.ParseError
+//     if errors.As(err, &e) {
+//         // e.Offset is 5, e.Text is "x"
+//     }
+//     errors.Is(err, approx.ErrBadDelta) // true
+type ParseError struct {
+	// Input is the parsed string.
+	Input string
+	// Offset is the byte offset of Text in Input.
+	Offset int
+	// Text is the part of Input which could not be parsed.
+	Text string
+	// Err is the kind of the error, one of ErrSyntax, ErrBadValue or
+	// ErrBadDelta.
+	Err error
+}
+
+// Error implements error.
+func (e *ParseError) Error() string {
+	return fmt.Sprintf("could not parse %q: %v %q at offset %v", e.Input, e.Err, e.Text, e.Offset)
+}
+
+// Unwrap returns the kind of the error, so that errors.Is(err, ErrBadValue)
+// and alike work.
+func (e *ParseError) Unwrap() error {
+	return e.Err
+}
+
+// parseError returns a ParseError of the kind err, for the part of s from
+// the byte offset start to end.
+func parseError(s string, start, end int, err error) error {
+	return &ParseError{Input: s, Offset: start, Text: s[start:end], Err: err}
+}
+
+// literal is an approximate number as written, with its numbers kept as
+// decimal literals, so that Parse, ParseBig and ParseDecimal can each convert
+// them to their own type, and all accept the same forms.
+type literal struct {
+	// val and delta are decimal literals, as accepted by strconv.ParseFloat,
+	// which may contain spaces.
+	val, delta string
+	// exact is set if there is no delta.
+	exact bool
+	// interval is set if val and delta are the endpoints of an interval.
+	interval bool
+	// percent is set if delta is in percent of val.
+	percent bool
+	// valAt and deltaAt are the byte offsets in the scanned string of the
+	// parts from which val and delta come, reported if these are invalid.
+	valAt, deltaAt [2]int
+}
+
+// valError returns the ParseError for the invalid value of l, scanned from s.
+func (l literal) valError(s string) error {
+	return parseError(s, l.valAt[0], l.valAt[1], ErrBadValue)
+}
+
+// deltaError returns the ParseError for the invalid delta of l, scanned from
+// s.  The maximum of an interval is a value.
+func (l literal) deltaError(s string) error {
+	if l.interval {
+		return parseError(s, l.deltaAt[0], l.deltaAt[1], ErrBadValue)
+	}
+	return parseError(s, l.deltaAt[0], l.deltaAt[1], ErrBadDelta)
+}
+
+// float64 converts l, scanned from s, to a Float64.
+func (l literal) float64(s string) (Float64, error) {
+	val, err := parseFloat(l.val)
+	if err != nil {
+		return Float64{}, l.valError(s)
+	}
+	if l.exact {
+		return Float64{val: val, delta: 0.0}, nil
+	}
+	delta, err := parseFloat(l.delta)
+	if err != nil {
+		return Float64{}, l.deltaError(s)
+	}
+	switch {
+	case l.interval:
+		if delta < val {
+			return Float64{}, parseError(s, 0, len(s), ErrBadValue)
+		}
+		return fromMinMax(val, delta), nil
+	case l.percent:
+		delta *= val / 100
+	}
+	return Float64{val: val, delta: math.Abs(delta)}, nil
+}
+
+// parseLiteral parses s in any of the forms accepted by Parse, converting the
+// scanned literal with conv.  The returned error is a *ParseError for s.
+func parseLiteral[T any](s string, conv func(literal, string) (T, error)) (T, error) {
+	if strings.ContainsAny(s, "[(~") {
+		t := stripSpaces(s)
+		l, err := scanForm(t)
+		if err != nil {
+			var zero T
+			return zero, unstrip(s, err)
+		}
+		f, err := conv(l, t)
+		return f, unstrip(s, err)
+	}
+	start, end := trimmed(s, 0, len(s))
+	l, err := scanPlusMinus(s[start:end])
+	var f T
+	if err == nil {
+		f, err = conv(l, s[start:end])
+	}
+	if e, ok := err.(*ParseError); ok {
+		e.Input, e.Offset = s, e.Offset+start
+	}
+	return f, err
+}
+
+// scanForm scans s without spaces in any of the forms accepted by Parse.
+func scanForm(s string) (literal, error) {
+	if strings.HasPrefix(s, "[") {
+		return scanInterval(s)
+	}
+	if strings.HasPrefix(s, "(") {
+		return scanShared(s)
+	}
+	if strings.HasPrefix(s, "~") {
+		return scanImplied(s)
+	}
+	if strings.Contains(s, "(") {
+		return scanConcise(s)
+	}
+	return scanPlusMinus(s)
+}
+
+// unstrip converts err, a ParseError for stripSpaces(s), to a ParseError for
+// s.
+func unstrip(s string, err error) error {
+	var e *ParseError
+	if !errors.As(err, &e) {
+		return err
+	}
+	// offset returns the byte offset in s of the byte i of stripSpaces(s).
+	offset := func(i int) int {
+		n := 0
+		for j, r := range s {
+			if unicode.IsSpace(r) {
+				continue
+			}
+			if n == i {
+				return j
+			}
+			n += len(string(r))
+		}
+		return len(s)
+	}
+	start, end := offset(e.Offset), offset(e.Offset+len(e.Text))
+	if e.Text == "" {
+		end = start
+	}
+	return &ParseError{Input: s, Offset: start, Text: strings.TrimSpace(s[start:end]), Err: e.Err}
+}
+
+// scanPlusMinus scans an exact number, or a number with a delta, such as
+// "4.2 ± 0.3" or "50+/-1%".  It scans s in place, so that parsing the most
+// common forms does not allocate.
+func scanPlusMinus(s string) (literal, error) {
+	i, n, j, m := plusMinus(s)
+	if i < 0 {
+		return literal{val: s, exact: true, valAt: [2]int{0, len(s)}}, nil
+	}
+	if j >= 0 {
+		return literal{}, parseError(s, j, j+m, ErrSyntax)
+	}
+	l := literal{val: s[:i]}
+	l.valAt[0], l.valAt[1] = trimmed(s, 0, i)
+	start, end := trimmed(s, i+n, len(s))
+	l.deltaAt = [2]int{start, end}
+	l.delta = s[start:end]
+	l.percent = strings.HasSuffix(l.delta, "%")
+	l.delta = strings.TrimSuffix(l.delta, "%")
+	return l, nil
+}
+
+// trimmed returns the byte offsets of s[start:end] with the spaces at either
+// end trimmed.
+func trimmed(s string, start, end int) (int, int) {
+	t := strings.TrimLeftFunc(s[start:end], unicode.IsSpace)
+	start = end - len(t)
+	return start, start + len(strings.TrimRightFunc(t, unicode.IsSpace))
+}
+
+// plusMinus returns the index and the length of the first separator of the
+// delta in s, either ±, +/- or +-, or -1 if there is none.  It also returns
+// the index and the length of the second separator, or -1 if there is none,
+// so that s is scanned only once.
+func plusMinus(s string) (i, n, j, m int) {
+	i, j = -1, -1
+	for k, r := range s {
+		var l int
+		switch {
+		case r == '±':
+			l = len("±")
+		case r != '+':
+			continue
+		case strings.HasPrefix(s[k:], "+/-"):
+			l = len("+/-")
+		case strings.HasPrefix(s[k:], "+-"):
+			l = len("+-")
+		default:
+			continue
+		}
+		if i >= 0 {
+			return i, n, k, l
+		}
+		i, n = k, l
+	}
+	return i, n, j, m
+}
+
+// parseFloat parses a float64, ignoring any spaces in s.
+func parseFloat(s string) (float64, error) {
+	return strconv.ParseFloat(unspaced(s), 64)
+}
+
+// unspaced returns s without spaces.  Unlike stripSpaces, it allocates only
+// if there are spaces within s, rather than at its ends.
+func unspaced(s string) string {
+	s = strings.TrimSpace(s)
+	if strings.IndexFunc(s, unicode.IsSpace) >= 0 {
+		s = stripSpaces(s)
+	}
+	return s
+}
+
+// stripSpaces returns s without spaces.
+func stripSpaces(s string) string {
+	return strings.Map(func(r rune) rune {
+		if unicode.IsSpace(r) {
+			return -1
+		}
+		return r
+	}, s)
+}
+
+// scanInterval scans an interval "[min,max]" without spaces.
+func scanInterval(s string) (literal, error) {
+	if !strings.HasSuffix(s, "]") {
+		return literal{}, parseError(s, 0, len(s), ErrSyntax)
+	}
+	i := strings.IndexByte(s, ',')
+	if i < 0 || strings.Count(s, ",") != 1 {
+		return literal{}, parseError(s, 0, len(s), ErrSyntax)
+	}
+	return literal{
+		val:      s[1:i],
+		delta:    s[i+1 : len(s)-1],
+		interval: true,
+		valAt:    [2]int{1, i},
+		deltaAt:  [2]int{i + 1, len(s) - 1},
+	}, nil
+}
+
+// scanImplied scans a number s without spaces, prefixed with ~, whose
+// implied uncertainty is half a unit in its last digit, e.g. "~50" is 50±0.5
+// and "~1.20e3" is 1200±5.
+func scanImplied(s string) (literal, error) {
+	num := s[len("~"):]
+	mant, exp := num, 0
+	if i := strings.IndexAny(num, "eE"); i >= 0 {
+		e, err := strconv.Atoi(num[i+1:])
+		if err != nil {
+			return literal{}, parseError(s, 1, len(s), ErrBadValue)
+		}
+		mant, exp = num[:i], e
+	}
+	if i := strings.IndexByte(mant, '.'); i >= 0 {
+		exp -= len(mant) - i - 1
+	}
+	// The delta is derived from the value, so it is invalid only if the
+	// value is.
+	at := [2]int{1, len(s)}
+	return literal{val: num, delta: "5e" + strconv.Itoa(exp-1), valAt: at, deltaAt: at}, nil
+}
+
+// scanConcise scans a number s without spaces in the concise notation, in
+// which the delta is given in parentheses as the uncertainty in the last
+// digits of the value, e.g. "1.234(5)" for 1.234±0.005.  The parenthesized
+// delta may also be given with a decimal point, in the units of the value,
+// e.g. "12.3(1.5)".  The number may be followed by an exponent, which applies
+// to both the value and the delta, e.g. "1.234(5)e-3", or as printed by
+// Concise, "1.234(5)×10⁻³".
+func scanConcise(s string) (literal, error) {
+	i, j := strings.IndexByte(s, '('), strings.IndexByte(s, ')')
+	if i <= 0 || j < i {
+		return literal{}, parseError(s, 0, len(s), ErrSyntax)
+	}
+	val, digits, rest := s[:i], s[i+1:j], s[j+1:]
+	exp, err := parseExponent(rest)
+	if err != nil {
+		return literal{}, parseError(s, j+1, len(s), ErrSyntax)
+	}
+	if digits == "" || strings.Trim(digits, "0123456789.") != "" {
+		return literal{}, parseError(s, i+1, j, ErrBadDelta)
+	}
+	// Without a decimal point, the delta is in the units of the last digit
+	// of the value.
+	dexp := exp
+	if !strings.Contains(digits, ".") {
+		if k := strings.IndexByte(val, '.'); k >= 0 {
+			dexp -= len(val) - k - 1
+		}
+	}
+	return literal{
+		val:     val + "e" + strconv.Itoa(exp),
+		delta:   digits + "e" + strconv.Itoa(dexp),
+		valAt:   [2]int{0, i},
+		deltaAt: [2]int{i + 1, j},
+	}, nil
+}
+
+// scanShared scans a number s without spaces, in the scientific notation
+// with an exponent shared by the value and the delta, e.g. "(1.23±0.04)e5".
+func scanShared(s string) (literal, error) {
+	j := strings.IndexByte(s, ')')
+	if j < 0 {
+		return literal{}, parseError(s, 0, len(s), ErrSyntax)
+	}
+	exp, err := parseExponent(s[j+1:])
+	if err != nil {
+		return literal{}, parseError(s, j+1, len(s), ErrSyntax)
+	}
+	i, n, _, _ := plusMinus(s[:j])
+	if i < 0 {
+		return literal{}, parseError(s, 0, len(s), ErrSyntax)
+	}
+	e := "e" + strconv.Itoa(exp)
+	l := literal{
+		val:     s[1:i] + e,
+		delta:   s[i+n : j],
+		valAt:   [2]int{1, i},
+		deltaAt: [2]int{i + n, j},
+	}
+	// A relative delta scales with the value.
+	l.percent = strings.HasSuffix(l.delta, "%")
+	if l.percent {
+		l.delta = strings.TrimSuffix(l.delta, "%")
+	} else {
+		l.delta += e
+	}
+	return l, nil
+}
+
+// parseExponent parses the exponent which follows a number, such as "e-3",
+// "×10^-3" or "×10⁻³".  The multiplication sign may also be written in ASCII
+// as x or *.  The empty string is the exponent 0.
+func parseExponent(s string) (int, error) {
+	switch {
+	case s == "":
+		return 0, nil
+	case s[0] == 'e' || s[0] == 'E':
+		s = s[1:]
+	case strings.HasPrefix(s, "×10") || strings.HasPrefix(s, "x10") || strings.HasPrefix(s, "*10"):
+		s = strings.TrimPrefix(strings.TrimLeft(s, "×x*")[len("10"):], "^")
+		s = strings.NewReplacer(
+			"⁻", "-", "⁺", "+", "⁰", "0", "¹", "1", "²", "2", "³", "3", "⁴", "4",
+			"⁵", "5", "⁶", "6", "⁷", "7", "⁸", "8", "⁹", "9",
+		).Replace(s)
+	default:
+		return 0, fmt.Errorf("invalid exponent: %q", s)
+	}
+	return strconv.Atoi(s)
+}
+
+// ParseSlice parses a list of approximate numbers, separated by commas,
+// semicolons or spaces, each in any form accepted by Parse.  This allows a
+// series of measurements to be read from a single configuration string.
+// Spaces are allowed around the separator of the delta, before a percent
+// sign, and within brackets and parentheses.  The error reports the index of
+// the first number which could not be parsed.
+//
+// Example:
+//     approx.ParseSlice("1±0.1, 2 ± 0.2; [2.5, 3.5] 4") // [1±0.1 2±0.2 3±0.5 4±0]
+func ParseSlice(s string) ([]Float64, error) {
+	var items []string
+	// glue is true if the next token continues the last item.
+	glue := false
+	for _, t := range sliceTokens(s) {
+		if glue || len(items) > 0 && !t.first && (hasPlusMinus(t.s, strings.HasPrefix) || t.s == "%") {
+			items[len(items)-1] += t.s
+		} else {
+			items = append(items, t.s)
+		}
+		glue = hasPlusMinus(t.s, strings.HasSuffix)
+	}
+	result := make([]Float64, 0, len(items))
+	for i, item := range items {
+		f, err := Parse(item)
+		if err != nil {
+			return nil, fmt.Errorf("could not parse item %v: %w", i, err)
+		}
+		result = append(result, f)
+	}
+	return result, nil
+}
+
+// sliceToken is a token of the input of ParseSlice.
+type sliceToken struct {
+	s string
+	// first is true if the token is first after a comma or a semicolon.
+	first bool
+}
+
+// sliceTokens splits s into the tokens separated by commas, semicolons or
+// spaces, except within brackets or parentheses.
+func sliceTokens(s string) []sliceToken {
+	var (
+		tokens []sliceToken
+		depth  int
+		start  = -1
+		first  = true
+	)
+	end := func(i int) {
+		if start >= 0 {
+			tokens = append(tokens, sliceToken{s: s[start:i], first: first})
+			first = false
+		}
+		start = -1
+	}
+	for i, r := range s {
+		switch {
+		case depth == 0 && (r == ',' || r == ';'):
+			end(i)
+			first = true
+			continue
+		case depth == 0 && unicode.IsSpace(r):
+			end(i)
+			continue
+		case r == '[' || r == '(':
+			depth++
+		case (r == ']' || r == ')') && depth > 0:
+			depth--
+		}
+		if start < 0 {
+			start = i
+		}
+	}
+	end(len(s))
+	return tokens
+}
+
+// hasPlusMinus returns true if has reports that s has any of the separators
+// of the delta, e.g. strings.HasSuffix.
+func hasPlusMinus(s string, has func(s, sep string) bool) bool {
+	return has(s, "±") || has(s, "+-") || has(s, "+/-")
+}
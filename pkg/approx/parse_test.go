@@ -0,0 +1,362 @@
+package approx
+
+import (
+	"errors"
+	"strings"
+	"testing"
+)
+
+func TestParseConcise(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected Float64
+		err      bool
+	}{
+		{input: "1.234(5)", expected: New(1.234, 0.005)},
+		{input: "1.234(56)", expected: New(1.234, 0.056)},
+		{input: "-1.234(5)", expected: New(-1.234, 0.005)},
+		{input: "1234(5)", expected: New(1234, 5)},
+		{input: "12.3(1.5)", expected: New(12.3, 1.5)},
+		{input: "1.234(5)e-3", expected: New(1.234e-3, 5e-6)},
+		{input: "1.234(5)E+3", expected: New(1234, 5)},
+		{input: " 1.234 (5) e3 ", expected: New(1234, 5)},
+		{input: "9.1093837015(28)×10⁻³¹", expected: New(9.1093837015e-31, 2.8e-40)},
+		{input: "1.235(68)×10^4", expected: New(12350, 680)},
+		{input: "0.000123(4)", expected: New(0.000123, 0.000004)},
+		{input: "(5)", err: true},
+		{input: "1.2(5", err: true},
+		{input: "1.2()", err: true},
+		{input: "1.2(-5)", err: true},
+		{input: "1.2(x)", err: true},
+		{input: "x(5)", err: true},
+		{input: "1.2(5)e", err: true},
+		{input: "1.2(5)×", err: true},
+		{input: "1.2(5)kg", err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			actual, err := Parse(test.input)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseConciseRoundTrip(t *testing.T) {
+	t.Parallel()
+	for _, f := range []Float64{
+		New(1.2345, 0.0012),
+		New(12350, 680),
+		New(9.1093837015e-31, 2.8e-40),
+		New(-0.000123, 0.000004),
+	} {
+		actual, err := Parse(f.Concise(2))
+		if err != nil {
+			t.Fatalf("unexpected error: %v", err)
+		}
+		if !near(actual, f) {
+			t.Errorf("expected: %v, actual: %v", f, actual)
+		}
+	}
+}
+
+func TestParseASCII(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected Float64
+		err      bool
+	}{
+		{input: "50 +- 0.5", expected: New(50, 0.5)},
+		{input: "50+-0.5", expected: New(50, 0.5)},
+		{input: "50 +/- 0.5", expected: New(50, 0.5)},
+		{input: "-1e+3 +/- 2e+1", expected: New(-1000, 20)},
+		{input: "~50", expected: New(50, 0.5)},
+		{input: "~ 50.0", expected: New(50, 0.05)},
+		{input: "~0.120", expected: New(0.12, 0.0005)},
+		{input: "~1.20e3", expected: New(1200, 5)},
+		{input: "~-7", expected: New(-7, 0.5)},
+		{input: "~5e-3", expected: New(0.005, 0.0005)},
+		{input: "50 +/ 0.5", err: true},
+		{input: "50 +- +- 0.5", err: true},
+		{input: "~", err: true},
+		{input: "~50±1", err: true},
+		{input: "~1e", err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			actual, err := Parse(test.input)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseInterval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected Float64
+		err      bool
+	}{
+		{input: "[49.5, 50.5]", expected: New(50, 0.5)},
+		{input: " [ -1 , 1 ] ", expected: New(0, 1)},
+		{input: "[2,2]", expected: New(2, 0)},
+		{input: "[1e3, 1.2e3]", expected: New(1100, 100)},
+		{input: "[50.5, 49.5]", err: true},
+		{input: "[49.5, 50.5", err: true},
+		{input: "[49.5]", err: true},
+		{input: "[1, 2, 3]", err: true},
+		{input: "[x, 2]", err: true},
+		{input: "[1, x]", err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			actual, err := Parse(test.input)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParsePercent(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected Float64
+		err      bool
+	}{
+		{input: "50 ± 1%", expected: New(50, 0.5)},
+		{input: "-50±1%", expected: New(-50, 0.5)},
+		{input: "100 +/- 0.5%", expected: New(100, 0.5)},
+		{input: "0±5%", expected: New(0, 0)},
+		{input: "2.2e3 ± 10 %", expected: New(2200, 220)},
+		{input: "50 ± %", err: true},
+		{input: "50 ± 1%%", err: true},
+		{input: "50% ± 1", err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			actual, err := Parse(test.input)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseShared(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected Float64
+		err      bool
+	}{
+		{input: "(1.23±0.04)e5", expected: New(123000, 4000)},
+		{input: "(1.23 ± 0.04)×10^5", expected: New(123000, 4000)},
+		{input: "(1.23 ± 0.04)×10⁵", expected: New(123000, 4000)},
+		{input: "(1.23 +/- 0.04) x 10^-5", expected: New(1.23e-5, 4e-7)},
+		{input: "(1.23+-0.04)*10^5", expected: New(123000, 4000)},
+		{input: "(-1.23±0.04)E+5", expected: New(-123000, 4000)},
+		{input: "(2±1%)e3", expected: New(2000, 20)},
+		{input: "(1.23±0.04)", expected: New(1.23, 0.04)},
+		{input: "1.23e5±4e3", expected: New(123000, 4000)},
+		{input: "(1.23±0.04", err: true},
+		{input: "(1.23)e5", err: true},
+		{input: "(1.23±0.04)e", err: true},
+		{input: "(1.23±0.04)×", err: true},
+		{input: "(1.23±0.04)×10^", err: true},
+		{input: "(1.23±0.04)m", err: true},
+		{input: "(x±0.04)e5", err: true},
+		{input: "(1.23±x)e5", err: true},
+		{input: "(1e1±0.04)e5", err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			actual, err := Parse(test.input)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestParseSlice(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected []Float64
+		err      string
+	}{
+		{input: "", expected: []Float64{}},
+		{input: " ,; ", expected: []Float64{}},
+		{input: "1±0.1, 2±0.2; 3", expected: []Float64{New(1, 0.1), New(2, 0.2), New(3, 0)}},
+		{input: "1±0.1 2±0.2\t3\n", expected: []Float64{New(1, 0.1), New(2, 0.2), New(3, 0)}},
+		{input: "1 ± 0.1, 2 +/- 0.2, 3+- 0.3", expected: []Float64{New(1, 0.1), New(2, 0.2), New(3, 0.3)}},
+		{input: "[2.5, 3.5], (1.2 ± 0.1)e3", expected: []Float64{New(3, 0.5), New(1200, 100)}},
+		{input: "1.2345(12);~50", expected: []Float64{New(1.2345, 0.0012), New(50, 0.5)}},
+		{input: "50 ± 1 %, 2±10%", expected: []Float64{New(50, 0.5), New(2, 0.2)}},
+		{input: "1, %", err: "item 1"},
+		{input: "1, ±0.1", err: "item 1"},
+		{input: "1, 2, x", err: "item 2"},
+		{input: "1 ±", err: "item 0"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			actual, err := ParseSlice(test.input)
+			if test.err != "" {
+				if err == nil || !strings.Contains(err.Error(), test.err) {
+					t.Fatalf("expected error containing %q, actual: %v", test.err, err)
+				}
+				return
+			}
+			if err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+			if len(actual) != len(test.expected) {
+				t.Fatalf("expected: %v, actual: %v", test.expected, actual)
+			}
+			for i := range actual {
+				if !near(actual[i], test.expected[i]) {
+					t.Errorf("expected: %v, actual: %v", test.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestParseAllocs(t *testing.T) {
+	for _, input := range []string{"4.2", "4.2±0.3", " 4.2 +/- 0.3 ", "50+-1%", "-1e-3 ± 2e-4"} {
+		allocs := testing.AllocsPerRun(100, func() {
+			if _, err := Parse(input); err != nil {
+				t.Fatalf("unexpected error: %v", err)
+			}
+		})
+		if allocs != 0 {
+			t.Errorf("Parse(%q): expected no allocations, got: %v", input, allocs)
+		}
+	}
+}
+
+func TestParseSpaces(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected Float64
+		err      bool
+	}{
+		{input: "\t4.2 ± 0.3\n", expected: New(4.2, 0.3)},
+		{input: "1 000 ± 1 0", expected: New(1000, 10)},
+		{input: "50 ± 1 %", expected: New(50, 0.5)},
+		{input: "1e+-5", err: true},
+		{input: "4.2±0.3±0.1", err: true},
+		{input: "4.2+-0.3+/-0.1", err: true},
+		{input: "±0.3", err: true},
+		{input: "", err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			actual, err := Parse(test.input)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if !test.err && !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func BenchmarkParse(b *testing.B) {
+	for _, input := range []string{"4.2±0.3", "4.2 +/- 0.3", "1.2345(12)e-3"} {
+		input := input
+		b.Run(input, func(b *testing.B) {
+			b.ReportAllocs()
+			for i := 0; i < b.N; i++ {
+				if _, err := Parse(input); err != nil {
+					b.Fatal(err)
+				}
+			}
+		})
+	}
+}
+
+func TestParseError(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected ParseError
+	}{
+		{input: "x", expected: ParseError{Offset: 0, Text: "x", Err: ErrBadValue}},
+		{input: "", expected: ParseError{Offset: 0, Text: "", Err: ErrBadValue}},
+		{input: "4.2±x", expected: ParseError{Offset: 5, Text: "x", Err: ErrBadDelta}},
+		{input: "  4.2 ± 0.3 x ", expected: ParseError{Offset: 9, Text: "0.3 x", Err: ErrBadDelta}},
+		{input: " x ± 0.3", expected: ParseError{Offset: 1, Text: "x", Err: ErrBadValue}},
+		{input: "4.2±0.3+-1", expected: ParseError{Offset: 8, Text: "+-", Err: ErrSyntax}},
+		{input: "[1, x]", expected: ParseError{Offset: 4, Text: "x", Err: ErrBadValue}},
+		{input: "[ x , 1]", expected: ParseError{Offset: 2, Text: "x", Err: ErrBadValue}},
+		{input: "[2, 1]", expected: ParseError{Offset: 0, Text: "[2, 1]", Err: ErrBadValue}},
+		{input: "[1, 2", expected: ParseError{Offset: 0, Text: "[1, 2", Err: ErrSyntax}},
+		{input: "~5x", expected: ParseError{Offset: 1, Text: "5x", Err: ErrBadValue}},
+		{input: "1.2(x)", expected: ParseError{Offset: 4, Text: "x", Err: ErrBadDelta}},
+		{input: "x(1)", expected: ParseError{Offset: 0, Text: "x", Err: ErrBadValue}},
+		{input: "1.2(3)m", expected: ParseError{Offset: 6, Text: "m", Err: ErrSyntax}},
+		{input: "(1.2 ± x)e3", expected: ParseError{Offset: 8, Text: "x", Err: ErrBadDelta}},
+		{input: "(y ± 1)e3", expected: ParseError{Offset: 1, Text: "y", Err: ErrBadValue}},
+		{input: "(1.2)e3", expected: ParseError{Offset: 0, Text: "(1.2)e3", Err: ErrSyntax}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			_, err := Parse(test.input)
+			var actual *ParseError
+			if !errors.As(err, &actual) {
+				t.Fatalf("expected a ParseError, got: %v", err)
+			}
+			test.expected.Input = test.input
+			if *actual != test.expected {
+				t.Errorf("expected: %+v, actual: %+v", test.expected, *actual)
+			}
+			if !errors.Is(err, test.expected.Err) {
+				t.Errorf("expected errors.Is(err, %v)", test.expected.Err)
+			}
+			if test.input[actual.Offset:actual.Offset+len(actual.Text)] != actual.Text {
+				t.Errorf("text %q not at offset %v", actual.Text, actual.Offset)
+			}
+		})
+	}
+}
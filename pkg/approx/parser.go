@@ -0,0 +1,124 @@
+package approx
+
+import (
+	"math"
+	"strings"
+	"unicode"
+	"unicode/utf8"
+)
+
+// Parser parses approximate numbers, same as Parse, with options which make it
+// stricter or more lenient.  The zero Parser is the same as Parse.
+//
+// Example, scraping measurements from a report:
+//     p := approx.Parser{Lenient: true}
+//     p.Parse("Temperature: 21.5 ± 0.5 °C (calibrated)") // 21.5±0.5
+//     p.Parse("approx. 50 ohm")                          // 50±0.5
+type Parser struct {
+	// Strict rejects NaN and infinite values and deltas, which Parse accepts.
+	Strict bool
+	// Lenient finds the first approximate number in the input, skipping any
+	// text around it, such as labels and units.  A number prefixed with
+	// "approx.", "ca." or "≈" is the same as prefixed with "~", that is it
+	// has the implied uncertainty of half a unit in its last digit.  Lenient
+	// parsing tries many substrings of the input, so it is meant for short
+	// texts.
+	Lenient bool
+}
+
+// approxPrefixes are the prefixes which mark a number as approximate in
+// lenient parsing, same as ~.
+var approxPrefixes = []string{"~", "≈", "approx.", "ca."}
+
+// Parse parses an approximate number from s, according to the options of p.
+// The returned error is a *ParseError.
+func (p Parser) Parse(s string) (Float64, error) {
+	var (
+		f          Float64
+		start, end int
+		err        error
+	)
+	if p.Lenient {
+		f, start, end, err = find(s)
+	} else {
+		f, err = Parse(s)
+		start, end = trimmed(s, 0, len(s))
+	}
+	if err != nil {
+		return Float64{}, err
+	}
+	if p.Strict {
+		switch {
+		case math.IsNaN(f.val) || math.IsInf(f.val, 0):
+			return Float64{}, parseError(s, start, end, ErrBadValue)
+		case math.IsNaN(f.delta) || math.IsInf(f.delta, 0):
+			return Float64{}, parseError(s, start, end, ErrBadDelta)
+		}
+	}
+	return f, nil
+}
+
+// find finds the first approximate number in s, and returns it together with
+// the byte offsets of its text in s.
+func find(s string) (f Float64, start, end int, err error) {
+	for i := 0; i < len(s); i++ {
+		prefix := ""
+		for _, w := range approxPrefixes {
+			if strings.HasPrefix(s[i:], w) {
+				prefix = w
+				break
+			}
+		}
+		j := i + len(prefix)
+		if prefix != "" {
+			j = len(s) - len(strings.TrimLeftFunc(s[j:], unicode.IsSpace))
+		}
+		if !numberStart(s[j:]) {
+			continue
+		}
+		f, end, err := longestParse(s, j)
+		if err != nil {
+			continue
+		}
+		if text := s[j:end]; prefix != "" && !strings.ContainsAny(text, "[(") {
+			if k, _, _, _ := plusMinus(text); k < 0 {
+				// A plain number has the implied uncertainty.
+				if g, err := Parse("~" + text); err == nil {
+					f = g
+				}
+			}
+		}
+		start, end = trimmed(s, i, end)
+		return f, start, end, nil
+	}
+	return Float64{}, 0, 0, parseError(s, 0, len(s), ErrSyntax)
+}
+
+// longestParse parses the longest approximate number in s which starts at the
+// byte offset start, and returns it with the byte offset of its end.
+func longestParse(s string, start int) (Float64, int, error) {
+	for end := len(s); end > start; {
+		if f, err := Parse(s[start:end]); err == nil {
+			return f, end, nil
+		}
+		_, size := utf8.DecodeLastRuneInString(s[:end])
+		end -= size
+	}
+	return Float64{}, 0, parseError(s, start, len(s), ErrSyntax)
+}
+
+// numberStart returns true if an approximate number may start at the
+// beginning of s: with a digit, optionally preceded by a sign and a decimal
+// point, and by a bracket or a parenthesis.
+func numberStart(s string) bool {
+	if s != "" && (s[0] == '[' || s[0] == '(') {
+		s = strings.TrimLeftFunc(s[1:], unicode.IsSpace)
+	}
+	if s != "" && (s[0] == '+' || s[0] == '-') {
+		s = s[1:]
+	}
+	if s != "" && s[0] == '.' {
+		s = s[1:]
+	}
+	return s != "" && '0' <= s[0] && s[0] <= '9'
+}
@@ -0,0 +1,65 @@
+package approx
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestParser(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		parser   Parser
+		input    string
+		expected Float64
+		err      *ParseError
+	}{
+		{name: "default", input: "4.2±0.3", expected: New(4.2, 0.3)},
+		{name: "default Inf", input: "Inf±1", expected: New(math.Inf(1), 1)},
+		{name: "default garbage", input: "4.2±0.3 mm", err: &ParseError{Offset: 5, Text: "0.3 mm", Err: ErrBadDelta}},
+		{name: "strict", parser: Parser{Strict: true}, input: " 4.2±0.3 ", expected: New(4.2, 0.3)},
+		{name: "strict NaN", parser: Parser{Strict: true}, input: " NaN±1 ", err: &ParseError{Offset: 1, Text: "NaN±1", Err: ErrBadValue}},
+		{name: "strict Inf", parser: Parser{Strict: true}, input: "-Inf", err: &ParseError{Offset: 0, Text: "-Inf", Err: ErrBadValue}},
+		{name: "strict Inf delta", parser: Parser{Strict: true}, input: "1±inf", err: &ParseError{Offset: 0, Text: "1±inf", Err: ErrBadDelta}},
+		{name: "strict garbage", parser: Parser{Strict: true}, input: "4.2x", err: &ParseError{Offset: 0, Text: "4.2x", Err: ErrBadValue}},
+		{name: "lenient", parser: Parser{Lenient: true}, input: "4.2±0.3", expected: New(4.2, 0.3)},
+		{name: "lenient unit", parser: Parser{Lenient: true}, input: "4.2±0.3mm", expected: New(4.2, 0.3)},
+		{name: "lenient text", parser: Parser{Lenient: true}, input: "Temperature: 21.5 ± 0.5 °C (calibrated)", expected: New(21.5, 0.5)},
+		{name: "lenient percent", parser: Parser{Lenient: true}, input: "R = 50 ± 1% ohm", expected: New(50, 0.5)},
+		{name: "lenient negative", parser: Parser{Lenient: true}, input: "offset -.5+/-0.1 V", expected: New(-0.5, 0.1)},
+		{name: "lenient interval", parser: Parser{Lenient: true}, input: "range: [ 1, 2 ] V", expected: New(1.5, 0.5)},
+		{name: "lenient concise", parser: Parser{Lenient: true}, input: "G = 6.67430(15)e-11 m³/kg/s²", expected: New(6.6743e-11, 1.5e-15)},
+		{name: "lenient approx", parser: Parser{Lenient: true}, input: "approx. 50 ohm", expected: New(50, 0.5)},
+		{name: "lenient ca", parser: Parser{Lenient: true}, input: "ca.1.20e3 m", expected: New(1200, 5)},
+		{name: "lenient ≈", parser: Parser{Lenient: true}, input: "≈ 7", expected: New(7, 0.5)},
+		{name: "lenient tilde", parser: Parser{Lenient: true}, input: "length ~50cm", expected: New(50, 0.5)},
+		{name: "lenient approx explicit", parser: Parser{Lenient: true}, input: "approx. 4.2 ± 0.3", expected: New(4.2, 0.3)},
+		{name: "lenient none", parser: Parser{Lenient: true}, input: "n/a (none)", err: &ParseError{Offset: 0, Text: "n/a (none)", Err: ErrSyntax}},
+		{name: "lenient strict", parser: Parser{Lenient: true, Strict: true}, input: "x = 1±inf m", err: &ParseError{Offset: 4, Text: "1±inf", Err: ErrBadDelta}},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual, err := test.parser.Parse(test.input)
+			if test.err == nil {
+				if err != nil {
+					t.Fatalf("unexpected error: %v", err)
+				}
+				if !near(actual, test.expected) {
+					t.Errorf("expected: %v, actual: %v", test.expected, actual)
+				}
+				return
+			}
+			var e *ParseError
+			if !errors.As(err, &e) {
+				t.Fatalf("expected a ParseError, got: %v", err)
+			}
+			test.err.Input = test.input
+			if *e != *test.err {
+				t.Errorf("expected: %+v, actual: %+v", *test.err, *e)
+			}
+		})
+	}
+}
@@ -0,0 +1,117 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+)
+
+// Poly is a polynomial with approximate coefficients, starting with the
+// constant term: Poly{a, b, c} is a + b*x + c*x^2.
+type Poly []Float64
+
+// Degree returns the degree of p, or -1 for the zero polynomial.  Trailing
+// coefficients which are exactly zero do not count.
+func (p Poly) Degree() int {
+	for i := len(p) - 1; i >= 0; i-- {
+		if p[i].val != 0 || p[i].delta != 0 {
+			return i
+		}
+	}
+	return -1
+}
+
+// Eval evaluates p at x, using Horner's method.
+//
+// Based on first order Taylor expansion around the coefficients and x:
+//   d(p(x)) = sum(|x^i| * dc_i) + |p'(x)| * dx
+// which accounts for x appearing in every term, unlike computing the same
+// from Mul and Add.
+func (p Poly) Eval(x Float64) Float64 {
+	var val, dval float64
+	for i := len(p) - 1; i >= 0; i-- {
+		dval = dval*x.val + val
+		val = val*x.val + p[i].val
+	}
+	r := New(val, math.Abs(dval)*x.delta).WithDistribution(x.dist)
+	xi := 1.0
+	for _, c := range p {
+		r = New(val, r.delta+math.Abs(xi)*c.delta).WithDistribution(combineDist(r, c))
+		xi *= x.val
+	}
+	return r
+}
+
+// Derivative returns the derivative of p.
+func (p Poly) Derivative() Poly {
+	if len(p) <= 1 {
+		return Poly{}
+	}
+	d := make(Poly, len(p)-1)
+	for i := range d {
+		d[i] = p[i+1].Mul(float64(i + 1))
+	}
+	return d
+}
+
+// Bracket returns the subintervals of [lo, hi] at whose ends the values of
+// p have opposite signs, so that each contains at least one root of p.  The
+// interval is scanned in n steps of equal width, so roots closer together
+// than a step may be missed.  An endpoint at which the value of p is exactly
+// zero is returned as a subinterval of zero width.
+func (p Poly) Bracket(lo, hi float64, n int) [][2]float64 {
+	var r [][2]float64
+	eval := func(x float64) float64 { return p.Eval(New(x, 0)).val }
+	x0, y0 := lo, eval(lo)
+	if y0 == 0 {
+		r = append(r, [2]float64{lo, lo})
+	}
+	for i := 1; i <= n; i++ {
+		x1 := lo + (hi-lo)*float64(i)/float64(n)
+		y1 := eval(x1)
+		switch {
+		case y1 == 0:
+			r = append(r, [2]float64{x1, x1})
+		case y0*y1 < 0:
+			r = append(r, [2]float64{x0, x1})
+		}
+		x0, y0 = x1, y1
+	}
+	return r
+}
+
+// Root finds a root of p between lo and hi, at whose ends the values of p
+// must have opposite signs, see Bracket.  Returns an error wrapping ErrNoRoot
+// otherwise.  For a polynomial of degree 1, a + b*x, the root is -a/b.
+//
+// The root is found from the values of the coefficients.  Its uncertainty
+// follows from the implicit function theorem, based on the first order
+// Taylor expansion of p(r) = 0 around the root r:
+//   dr = sum(|r^i| * dc_i) / |p'(r)|
+//
+// At a multiple root, p'(r) is zero, and the delta is infinite.
+func (p Poly) Root(lo, hi float64) (Float64, error) {
+	f := func(x float64) float64 { return p.Eval(New(x, 0)).val }
+	r, err := bisect(f, lo, hi)
+	if err != nil {
+		return Float64{}, fmt.Errorf("could not find root of %v: %w", p, err)
+	}
+	// The uncertainty of p(r), with r taken as exact, is the numerator of dr.
+	pr := p.Eval(New(r, 0))
+	dp := p.Derivative().Eval(New(r, 0)).val
+	if pr.delta == 0 {
+		return New(r, 0), nil
+	}
+	return New(r, pr.delta/math.Abs(dp)).WithDistribution(pr.dist), nil
+}
+
+// Roots finds the roots of p between lo and hi, by scanning the interval in
+// n steps for sign changes, see Bracket, and refining each with Root.
+func (p Poly) Roots(lo, hi float64, n int) []Float64 {
+	var roots []Float64
+	for _, b := range p.Bracket(lo, hi, n) {
+		if r, err := p.Root(b[0], b[1]); err == nil {
+			roots = append(roots, r)
+		}
+	}
+	return roots
+}
@@ -0,0 +1,147 @@
+package approx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPolyEval(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		p        Poly
+		x        Float64
+		expected Float64
+	}{
+		{p: Poly{New(1, 0.1), New(2, 0), New(3, 0.2)}, x: New(2, 0.1), expected: New(17, 2.3)},
+		{p: Poly{New(1, 0.1), New(2, 0), New(3, 0.2)}, x: New(-1, 0), expected: New(2, 0.3)},
+		{p: Poly{New(0, 0), New(0, 0), New(1, 0)}, x: New(0, 0.1), expected: New(0, 0)},
+		{p: Poly{New(0, 0), New(0, 0), New(1, 0)}, x: New(3, 0.1).WithDistribution(Uniform), expected: New(9, 0.6).WithDistribution(Uniform)},
+		{p: Poly{}, x: New(3, 0.1), expected: New(0, 0)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v(%v)", test.p, test.x), func(t *testing.T) {
+			if actual := test.p.Eval(test.x); !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPolyDerivative(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		p        Poly
+		expected Poly
+		degree   int
+	}{
+		{p: Poly{New(1, 0.1), New(2, 0), New(3, 0.2)}, expected: Poly{New(2, 0), New(6, 0.4)}, degree: 2},
+		{p: Poly{New(1, 0.1)}, expected: Poly{}, degree: 0},
+		{p: Poly{New(1, 0.1), New(0, 0)}, expected: Poly{New(0, 0)}, degree: 0},
+		{p: Poly{}, expected: Poly{}, degree: -1},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprint(test.p), func(t *testing.T) {
+			if actual := test.p.Derivative(); !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+			if actual := test.p.Degree(); actual != test.degree {
+				t.Errorf("expected degree: %v, actual: %v", test.degree, actual)
+			}
+		})
+	}
+}
+
+func TestPolyBracket(t *testing.T) {
+	t.Parallel()
+	p := Poly{New(-1, 0), New(0, 0), New(1, 0)}
+	tests := []struct {
+		n        int
+		expected [][2]float64
+	}{
+		{n: 4, expected: [][2]float64{{-1, -1}, {1, 1}}},
+		{n: 3, expected: [][2]float64{{-2, -0.6666666666666667}, {0.6666666666666665, 2}}},
+		{n: 1},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprint(test.n), func(t *testing.T) {
+			if actual := p.Bracket(-2, 2, test.n); !cmp.Equal(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPolyRoot(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		p        Poly
+		lo, hi   float64
+		expected Float64
+		err      error
+	}{
+		{
+			name:     "linear",
+			p:        Poly{New(-4, 0.1), New(2, 0.1)},
+			lo:       0,
+			hi:       10,
+			expected: New(2, 0.15),
+		},
+		{
+			name:     "quadratic",
+			p:        Poly{New(-2, 0.1), New(0, 0), New(1, 0)},
+			lo:       0,
+			hi:       2,
+			expected: New(math.Sqrt2, 0.1/(2*math.Sqrt2)),
+		},
+		{
+			name:     "exact",
+			p:        Poly{New(-1, 0), New(0, 0), New(1, 0)},
+			lo:       0,
+			hi:       3,
+			expected: New(1, 0),
+		},
+		{
+			name: "no root",
+			p:    Poly{New(1, 0), New(0, 0), New(1, 0)},
+			lo:   -1,
+			hi:   1,
+			err:  ErrNoRoot,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := test.p.Root(test.lo, test.hi)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if err == nil && !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPolyRoots(t *testing.T) {
+	t.Parallel()
+	// (x-1)*(x-2)*(x-3), with an uncertain constant term.
+	p := Poly{New(-6, 0.06), New(11, 0), New(-6, 0), New(1, 0)}
+	expected := []Float64{New(1, 0.03), New(2, 0.06), New(3, 0.03)}
+	actual := p.Roots(0, 4, 40)
+	if len(actual) != len(expected) {
+		t.Fatalf("expected: %v, actual: %v", expected, actual)
+	}
+	for i := range actual {
+		if !near(actual[i], expected[i]) {
+			t.Errorf("expected: %v, actual: %v", expected, actual)
+		}
+	}
+}
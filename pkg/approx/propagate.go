@@ -0,0 +1,481 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+	"strings"
+	"sync"
+)
+
+// Propagator decides how the uncertainties of the operands combine into the
+// uncertainty of the result of a basic arithmetic operation.
+//
+// Different measurement situations call for different statistical models.
+// Package-level functions Add, Sub, Mul and Div delegate to the propagator
+// set by SetPropagator, so the model can be switched without rewriting the
+// formulas that use them.  A Propagator may also be used directly:
+//
+//     q := approx.Quadrature{}
+//     sum := q.Add(a, b)
+type Propagator interface {
+	// Add computes a+b.
+	Add(a, b Float64) Float64
+	// Sub computes a-b.
+	Sub(a, b Float64) Float64
+	// Mul computes a*b.
+	Mul(a, b Float64) Float64
+	// Div computes a/b.
+	Div(a, b Float64) Float64
+}
+
+// fmaPropagator is implemented by the Propagators which compute a*b+c in a
+// single step, see FMA.
+type fmaPropagator interface {
+	FMA(a, b, c Float64) Float64
+}
+
+// WorstCase is a Propagator which assumes that the errors of the operands
+// may conspire to make the result as bad as possible.  Absolute errors add up
+// for sums and differences, relative errors add up for products and
+// quotients.
+//
+// This is the default propagator.
+type WorstCase struct{}
+
+var _ Propagator = WorstCase{}
+
+// Add implements Propagator.
+func (WorstCase) Add(a, b Float64) Float64 {
+	return New(a.val+b.val, a.delta+b.delta).WithDistribution(combineDist(a, b))
+}
+
+// Sub implements Propagator.
+func (WorstCase) Sub(a, b Float64) Float64 {
+	return New(a.val-b.val, a.delta+b.delta).WithDistribution(combineDist(a, b))
+}
+
+// Mul implements Propagator.
+//
+// If the value of either operand is zero or too small for its relative error
+// to be computed, the absolute errors are propagated instead:
+//   d(a*b) = |b|*da + |a|*db
+func (WorstCase) Mul(a, b Float64) Float64 {
+	if tiny(a.val) || tiny(b.val) {
+		delta := math.Abs(b.val)*a.delta + math.Abs(a.val)*b.delta
+		return New(a.val*b.val, delta).WithDistribution(combineDist(a, b))
+	}
+	relA := math.Abs(a.delta / a.val)
+	relB := math.Abs(b.delta / b.val)
+	rel := relA + relB
+	val := a.val * b.val
+	delta := math.Abs(val * rel)
+	return New(val, delta).WithDistribution(combineDist(a, b))
+}
+
+// FMA computes a*b+c in a single step, see the function FMA.  The
+// uncertainty is that of Mul(a, b), plus dc.
+func (WorstCase) FMA(a, b, c Float64) Float64 {
+	p := WorstCase{}.Mul(a, b)
+	return New(math.FMA(a.val, b.val, c.val), p.delta+c.delta).WithDistribution(combineDist(p, c))
+}
+
+// Div implements Propagator.
+//
+// If the value of a is zero or tiny, the absolute errors are propagated
+// instead:
+//   d(a/b) = (da + |a/b|*db) / |b|
+// If the value of b is zero or tiny, the quotient is computed with interval
+// arithmetic, see Interval.  Note that the quotient is unbounded if the
+// interval of b contains zero, see DivChecked.
+func (WorstCase) Div(a, b Float64) Float64 {
+	if tiny(b.val) {
+		return Interval{}.Div(a, b)
+	}
+	if tiny(a.val) {
+		val := a.val / b.val
+		delta := (a.delta + math.Abs(val)*b.delta) / math.Abs(b.val)
+		return New(val, delta).WithDistribution(combineDist(a, b))
+	}
+	relA := math.Abs(a.delta / a.val)
+	relB := math.Abs(b.delta / b.val)
+	rel := relA + relB
+	val := a.val / b.val
+	delta := math.Abs(val * rel)
+	return New(val, delta).WithDistribution(combineDist(a, b))
+}
+
+// tiny returns true if x is zero or subnormal, so that dividing by x may
+// overflow.
+func tiny(x float64) bool {
+	return math.Abs(x) < minNormal
+}
+
+// minNormal is the smallest positive normal float64.
+const minNormal = 2.2250738585072014e-308
+
+// Quadrature is a Propagator which assumes that the errors of the operands
+// are statistically independent.  The standard uncertainties then add up in
+// quadrature (root-sum-square), based on the first order Taylor expansion of
+// the operation.
+//
+// The standard uncertainty of an operand is obtained from its delta according
+// to its Distribution.  When both operands are uncertain and at least one has
+// a declared distribution, the result is Gaussian.
+type Quadrature struct{}
+
+var _ Propagator = Quadrature{}
+
+// Add implements Propagator.
+func (Quadrature) Add(a, b Float64) Float64 {
+	return quadrature(a.val+b.val, 1, a, 1, b)
+}
+
+// Sub implements Propagator.
+func (Quadrature) Sub(a, b Float64) Float64 {
+	return quadrature(a.val-b.val, 1, a, -1, b)
+}
+
+// Mul implements Propagator.
+//
+//   d(a*b) = sqrt((b*da)^2 + (a*db)^2)
+func (Quadrature) Mul(a, b Float64) Float64 {
+	return quadrature(a.val*b.val, b.val, a, a.val, b)
+}
+
+// Div implements Propagator.
+//
+//   d(a/b) = sqrt((da/b)^2 + (a*db/b^2)^2)
+func (Quadrature) Div(a, b Float64) Float64 {
+	return quadrature(a.val/b.val, 1/b.val, a, -a.val/(b.val*b.val), b)
+}
+
+// FMA computes a*b+c in a single step, see the function FMA.
+//
+//   d(a*b+c) = sqrt((b*da)^2 + (a*db)^2 + dc^2)
+func (Quadrature) FMA(a, b, c Float64) Float64 {
+	p := quadrature(0, b.val, a, a.val, b)
+	return quadrature(math.FMA(a.val, b.val, c.val), 1, p, 1, c)
+}
+
+// quadrature returns val with the uncertainty obtained by adding in
+// quadrature the uncertainties of a and b, scaled by the sensitivities ca and
+// cb of the result to a and b, respectively.
+func quadrature(val, ca float64, a Float64, cb float64, b Float64) Float64 {
+	switch {
+	case a.delta == 0:
+		return New(val, cb*b.delta).WithDistribution(b.dist)
+	case b.delta == 0:
+		return New(val, ca*a.delta).WithDistribution(a.dist)
+	}
+	return New(val, math.Hypot(ca*a.StdDev(), cb*b.StdDev())).WithDistribution(quadratureDist(a, b))
+}
+
+// AddQ computes a sum of a and b, adding the uncertainties in quadrature
+// regardless of the current Propagator.
+func AddQ(a, b Float64) Float64 {
+	return Quadrature{}.Add(a, b)
+}
+
+// SubQ computes a difference of a and b, adding the uncertainties in
+// quadrature regardless of the current Propagator.
+func SubQ(a, b Float64) Float64 {
+	return Quadrature{}.Sub(a, b)
+}
+
+// MulQ computes a product of a and b, adding the uncertainties in quadrature
+// regardless of the current Propagator.
+func MulQ(a, b Float64) Float64 {
+	return Quadrature{}.Mul(a, b)
+}
+
+// DivQ computes a quotient of a and b, adding the uncertainties in
+// quadrature regardless of the current Propagator.
+func DivQ(a, b Float64) Float64 {
+	return Quadrature{}.Div(a, b)
+}
+
+// Interval is a Propagator which treats each approximate number as the
+// closed interval [Min(), Max()], and computes the result as the interval of
+// all values that the operation can attain when the operands range over
+// their intervals.
+//
+// Products and quotients are computed from the four combinations of the
+// interval endpoints, so that the result is an enclosure of all possible
+// outcomes.  Other propagators use first order approximations, which
+// underestimate the uncertainty of products and quotients of wide
+// intervals.  Note that the center of the resulting interval need not be the
+// product (or quotient) of the centers of the operands.
+//
+// The endpoints are computed in float64 arithmetic, which rounds to the
+// nearest representable number.  The result may therefore miss the exact
+// enclosure by a rounding error.  Set Outward to obtain rigorous enclosures.
+type Interval struct {
+	// Outward, if set, rounds all computed endpoints outward, toward -Inf
+	// for the lower and toward +Inf for the upper endpoint, so that the
+	// result is a rigorous enclosure despite the float64 rounding.  The
+	// intervals grow by a few ulps per operation.
+	Outward bool
+}
+
+var _ Propagator = Interval{}
+
+// Add implements Propagator.
+func (i Interval) Add(a, b Float64) Float64 {
+	amin, amax := i.bounds(a)
+	bmin, bmax := i.bounds(b)
+	return i.hull(amin+bmin, amax+bmax).WithDistribution(combineDist(a, b))
+}
+
+// Sub implements Propagator.
+func (i Interval) Sub(a, b Float64) Float64 {
+	amin, amax := i.bounds(a)
+	bmin, bmax := i.bounds(b)
+	return i.hull(amin-bmax, amax-bmin).WithDistribution(combineDist(a, b))
+}
+
+// Mul implements Propagator.
+func (i Interval) Mul(a, b Float64) Float64 {
+	amin, amax := i.bounds(a)
+	bmin, bmax := i.bounds(b)
+	return i.hull(
+		amin*bmin, amin*bmax,
+		amax*bmin, amax*bmax).WithDistribution(combineDist(a, b))
+}
+
+// Div implements Propagator.
+//
+// If the interval of b contains zero, the quotient is unbounded, and the
+// result has an infinite delta.  Its value is then the quotient of the values
+// of a and b, or 0 where that is not finite, e.g. for 0/(0±1).
+func (i Interval) Div(a, b Float64) Float64 {
+	amin, amax := i.bounds(a)
+	bmin, bmax := i.bounds(b)
+	if bmin <= 0 && 0 <= bmax {
+		val := a.val / b.val
+		if math.IsNaN(val) || math.IsInf(val, 0) {
+			val = 0
+		}
+		return New(val, math.Inf(1)).WithDistribution(combineDist(a, b))
+	}
+	return i.hull(
+		amin/bmin, amin/bmax,
+		amax/bmin, amax/bmax).WithDistribution(combineDist(a, b))
+}
+
+// FMA computes a*b+c in a single step, see the function FMA.  The endpoints
+// are computed with math.FMA from the endpoints of a, b and c, so that each
+// has only one rounding.  The result is the interval between them, whose
+// center need not be the FMA of the values of a, b and c.
+func (i Interval) FMA(a, b, c Float64) Float64 {
+	amin, amax := i.bounds(a)
+	bmin, bmax := i.bounds(b)
+	cmin, cmax := i.bounds(c)
+	return i.hull(
+		math.FMA(amin, bmin, cmin), math.FMA(amin, bmax, cmin),
+		math.FMA(amax, bmin, cmin), math.FMA(amax, bmax, cmin),
+		math.FMA(amin, bmin, cmax), math.FMA(amin, bmax, cmax),
+		math.FMA(amax, bmin, cmax), math.FMA(amax, bmax, cmax),
+	).WithDistribution(combineDist(Interval{}.Mul(a, b), c))
+}
+
+// bounds returns the endpoints of f, rounded outward if requested.
+func (i Interval) bounds(f Float64) (min, max float64) {
+	if !i.Outward {
+		return f.Min(), f.Max()
+	}
+	return down(f.Min()), up(f.Max())
+}
+
+// hull returns the smallest approximate number which contains all of xs,
+// rounded outward if requested.
+func (i Interval) hull(xs ...float64) Float64 {
+	if !i.Outward {
+		return hull(xs...)
+	}
+	min, max := xs[0], xs[0]
+	for _, x := range xs[1:] {
+		min = math.Min(min, x)
+		max = math.Max(max, x)
+	}
+	// Each endpoint was obtained by a single rounded operation, and is
+	// within an ulp of the exact result.
+	min, max = down(min), up(max)
+	val := min + (max-min)/2
+	delta := up(math.Max(up(max-val), up(val-min)))
+	return New(val, delta)
+}
+
+// down returns the largest float64 smaller than x.
+func down(x float64) float64 {
+	return math.Nextafter(x, math.Inf(-1))
+}
+
+// up returns the smallest float64 larger than x.
+func up(x float64) float64 {
+	return math.Nextafter(x, math.Inf(1))
+}
+
+// AddI computes a sum of a and b with interval arithmetic, regardless of the
+// current Propagator.
+func AddI(a, b Float64) Float64 {
+	return Interval{}.Add(a, b)
+}
+
+// SubI computes a difference of a and b with interval arithmetic, regardless
+// of the current Propagator.
+func SubI(a, b Float64) Float64 {
+	return Interval{}.Sub(a, b)
+}
+
+// MulI computes a product of a and b with interval arithmetic, regardless of
+// the current Propagator.  Unlike Mul with the default propagator, the result
+// is guaranteed to contain all products of values from a and b, even when the
+// intervals are wide.
+func MulI(a, b Float64) Float64 {
+	return Interval{}.Mul(a, b)
+}
+
+// DivI computes a quotient of a and b with interval arithmetic, regardless of
+// the current Propagator.  Unlike Div with the default propagator, the result
+// is guaranteed to contain all quotients of values from a and b, even when
+// the intervals are wide.
+func DivI(a, b Float64) Float64 {
+	return Interval{}.Div(a, b)
+}
+
+// Guard is a Propagator which checks the results of another Propagator for
+// NaN and infinite values and deltas, which otherwise propagate silently
+// through a computation.  By default, Guard records the first such result,
+// to be queried by Err once the computation is done.  With Panic set, Guard
+// panics instead, at the operation which produced it.
+//
+// Guard checks the arithmetic which goes through it.  Set it with
+// SetPropagator to check Add, Sub, Mul, Div, and the other functions which
+// use the current Propagator:
+//
+//     g := &approx.Guard{}
+//     defer approx.SetPropagator(approx.SetPropagator(g))
+//     r := approx.Div(a, b)
+//     // ...
+//     if err := g.Err(); err != nil {
+//         // A result was NaN or infinite.
+//     }
+//
+// Guard is safe for concurrent use.
+type Guard struct {
+	// Propagator computes the results.  If nil, it is WorstCase.
+	Propagator Propagator
+	// Panic, if set, makes Guard panic with the error, rather than record
+	// it.
+	Panic bool
+
+	mu  sync.Mutex
+	err error
+}
+
+var _ Propagator = &Guard{}
+
+// Add implements Propagator.
+func (g *Guard) Add(a, b Float64) Float64 {
+	return g.check(g.propagator().Add(a, b), "Add", a, b)
+}
+
+// Sub implements Propagator.
+func (g *Guard) Sub(a, b Float64) Float64 {
+	return g.check(g.propagator().Sub(a, b), "Sub", a, b)
+}
+
+// Mul implements Propagator.
+func (g *Guard) Mul(a, b Float64) Float64 {
+	return g.check(g.propagator().Mul(a, b), "Mul", a, b)
+}
+
+// Div implements Propagator.
+func (g *Guard) Div(a, b Float64) Float64 {
+	return g.check(g.propagator().Div(a, b), "Div", a, b)
+}
+
+// FMA computes a*b+c in a single step if the Propagator of g does, see the
+// function FMA.
+func (g *Guard) FMA(a, b, c Float64) Float64 {
+	return g.check(fma(g.propagator(), a, b, c), "FMA", a, b, c)
+}
+
+// Err returns the error for the first NaN or infinite result since g was
+// created or reset, or nil if there was none.  The error wraps ErrInvalid.
+func (g *Guard) Err() error {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	return g.err
+}
+
+// Reset forgets the recorded error, so that g can check another computation.
+func (g *Guard) Reset() {
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	g.err = nil
+}
+
+// propagator returns the Propagator which computes the results of g.
+func (g *Guard) propagator() Propagator {
+	if g.Propagator == nil {
+		return WorstCase{}
+	}
+	return g.Propagator
+}
+
+// check records or panics on r, the result of the operation op on args, if
+// it is not finite.  Returns r.
+func (g *Guard) check(r Float64, op string, args ...Float64) Float64 {
+	if r.IsFinite() {
+		return r
+	}
+	strs := make([]string, len(args))
+	for i, a := range args {
+		strs[i] = a.String()
+	}
+	err := fmt.Errorf("%v(%v): %w", op, strings.Join(strs, ", "), r.Validate())
+	if g.Panic {
+		panic(err)
+	}
+	g.mu.Lock()
+	defer g.mu.Unlock()
+	if g.err == nil {
+		g.err = err
+	}
+	return r
+}
+
+// propagator is used by Add, Sub, Mul and Div.
+var propagator Propagator = WorstCase{}
+
+// SetPropagator makes p the Propagator used by Add, Sub, Mul and Div, and
+// returns the previously used Propagator so that it can be restored later.
+//
+// SetPropagator is meant to be called during program initialization.  It is
+// not safe to call it concurrently with the arithmetic functions.
+func SetPropagator(p Propagator) Propagator {
+	prev := propagator
+	propagator = p
+	return prev
+}
+
+// CurrentPropagator returns the Propagator used by Add, Sub, Mul and Div.
+func CurrentPropagator() Propagator {
+	return propagator
+}
+
+// fromMinMax is NewMinMax for callers that know that min <= max.
+func fromMinMax(min, max float64) Float64 {
+	return New((min+max)/2, (max-min)/2)
+}
+
+// hull returns the smallest approximate number which contains all of xs.
+func hull(xs ...float64) Float64 {
+	min, max := xs[0], xs[0]
+	for _, x := range xs[1:] {
+		min = math.Min(min, x)
+		max = math.Max(max, x)
+	}
+	return fromMinMax(min, max)
+}
@@ -0,0 +1,390 @@
+package approx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestPropagators(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		p        Propagator
+		op1, op2 Float64
+		sum      Float64
+		sub      Float64
+		product  Float64
+		quotient Float64
+	}{
+		{
+			name:     "worst case",
+			p:        WorstCase{},
+			op1:      New(10, 1),
+			op2:      New(5, 1),
+			sum:      New(15, 2),
+			sub:      New(5, 2),
+			product:  New(50, 15.000000000000002),
+			quotient: New(2, 0.6000000000000001),
+		},
+		{
+			name:     "quadrature",
+			p:        Quadrature{},
+			op1:      New(10, 3),
+			op2:      New(5, 4),
+			sum:      New(15, 5),
+			sub:      New(5, 5),
+			product:  New(50, 42.720018726587654),
+			quotient: New(2, 1.7088007490635062),
+		},
+		{
+			name:     "interval",
+			p:        Interval{},
+			op1:      New(10, 1),
+			op2:      New(5, 1),
+			sum:      New(15, 2),
+			sub:      New(5, 2),
+			product:  New(51, 15),
+			quotient: New(2.125, 0.625),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v:(%v;%v)", test.name, test.op1, test.op2), func(t *testing.T) {
+			sum := test.p.Add(test.op1, test.op2)
+			if !cmp.Equal(sum, test.sum, opts...) {
+				t.Errorf("sum: expected: %v, actual: %v", test.sum, sum)
+			}
+			sub := test.p.Sub(test.op1, test.op2)
+			if !cmp.Equal(sub, test.sub, opts...) {
+				t.Errorf("sub: expected: %v, actual: %v", test.sub, sub)
+			}
+			product := test.p.Mul(test.op1, test.op2)
+			if !cmp.Equal(product, test.product, opts...) {
+				t.Errorf("product: expected: %v, actual: %v", test.product, product)
+			}
+			quotient := test.p.Div(test.op1, test.op2)
+			if !cmp.Equal(quotient, test.quotient, opts...) {
+				t.Errorf("quotient: expected: %v, actual: %v", test.quotient, quotient)
+			}
+		})
+	}
+}
+
+func TestWorstCaseZero(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		actual   Float64
+		expected Float64
+	}{
+		{
+			name:     "mul zero center",
+			actual:   WorstCase{}.Mul(New(0, 1), New(3, 0.1)),
+			expected: New(0, 3),
+		},
+		{
+			name:     "mul exact zero",
+			actual:   WorstCase{}.Mul(New(0, 0), New(3, 0.1)),
+			expected: New(0, 0),
+		},
+		{
+			name:     "mul subnormal",
+			actual:   WorstCase{}.Mul(New(3, 0.1), New(5e-324, 1)),
+			expected: New(1.5e-323, 3),
+		},
+		{
+			name:     "div zero numerator",
+			actual:   WorstCase{}.Div(New(0, 1), New(2, 0.1)),
+			expected: New(0, 0.5),
+		},
+		{
+			name:     "div exact zero numerator",
+			actual:   WorstCase{}.Div(New(0, 0), New(2, 0.1)),
+			expected: New(0, 0),
+		},
+		{
+			name:     "div zero denominator",
+			actual:   WorstCase{}.Div(New(1, 0), New(0, 0.5)),
+			expected: New(0, math.Inf(1)),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if !cmp.Equal(test.actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
+
+func TestIntervalDivByZero(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		a, b     Float64
+		expected Float64
+	}{
+		{name: "nonzero", a: New(1, 0), b: New(0.5, 1), expected: New(2, math.Inf(1))},
+		{name: "zero by zero", a: New(0, 0), b: New(0, 1), expected: New(0, math.Inf(1))},
+		{name: "one by zero", a: New(1, 0), b: New(0, 1), expected: New(0, math.Inf(1))},
+		{name: "distribution", a: New(0, 0), b: New(0, 1).WithDistribution(Uniform), expected: New(0, math.Inf(1)).WithDistribution(Uniform)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := Interval{}.Div(test.a, test.b)
+			if !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestQuadratureOps(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		op1, op2 Float64
+		sum      Float64
+		sub      Float64
+		product  Float64
+		quotient Float64
+	}{
+		{
+			op1:      New(1, 0.3),
+			op2:      New(2, 0.4),
+			sum:      New(3, 0.5),
+			sub:      New(-1, 0.5),
+			product:  New(2, 0.7211102550927978),
+			quotient: New(0.5, 0.18027756377319945),
+		},
+		{
+			op1:      New(4, 0),
+			op2:      New(2, 0),
+			sum:      New(6, 0),
+			sub:      New(2, 0),
+			product:  New(8, 0),
+			quotient: New(2, 0),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("(%v;%v)", test.op1, test.op2), func(t *testing.T) {
+			sum := AddQ(test.op1, test.op2)
+			if !cmp.Equal(sum, test.sum, opts...) {
+				t.Errorf("sum: expected: %v, actual: %v", test.sum, sum)
+			}
+			sub := SubQ(test.op1, test.op2)
+			if !cmp.Equal(sub, test.sub, opts...) {
+				t.Errorf("sub: expected: %v, actual: %v", test.sub, sub)
+			}
+			product := MulQ(test.op1, test.op2)
+			if !cmp.Equal(product, test.product, opts...) {
+				t.Errorf("product: expected: %v, actual: %v", test.product, product)
+			}
+			quotient := DivQ(test.op1, test.op2)
+			if !cmp.Equal(quotient, test.quotient, opts...) {
+				t.Errorf("quotient: expected: %v, actual: %v", test.quotient, quotient)
+			}
+		})
+	}
+}
+
+func TestIntervalOps(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		op1, op2 Float64
+		sum      Float64
+		sub      Float64
+		product  Float64
+		quotient Float64
+	}{
+		{
+			op1:      New(1, 2),
+			op2:      New(3, 4),
+			sum:      New(4, 6),
+			sub:      New(-2, 6),
+			product:  New(7, 14),
+			quotient: New(1.0/3, math.Inf(1)),
+		},
+		{
+			op1:      New(10, 5),
+			op2:      New(2, 1),
+			sum:      New(12, 6),
+			sub:      New(8, 6),
+			product:  New(25, 20),
+			quotient: New(8.333333333333334, 6.666666666666667),
+		},
+		{
+			op1:      New(-4, 1),
+			op2:      New(-2, 1),
+			sum:      New(-6, 2),
+			sub:      New(-2, 2),
+			product:  New(9, 6),
+			quotient: New(3, 2),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("(%v;%v)", test.op1, test.op2), func(t *testing.T) {
+			sum := AddI(test.op1, test.op2)
+			if !cmp.Equal(sum, test.sum, opts...) {
+				t.Errorf("sum: expected: %v, actual: %v", test.sum, sum)
+			}
+			sub := SubI(test.op1, test.op2)
+			if !cmp.Equal(sub, test.sub, opts...) {
+				t.Errorf("sub: expected: %v, actual: %v", test.sub, sub)
+			}
+			product := MulI(test.op1, test.op2)
+			if !cmp.Equal(product, test.product, opts...) {
+				t.Errorf("product: expected: %v, actual: %v", test.product, product)
+			}
+			quotient := DivI(test.op1, test.op2)
+			if !cmp.Equal(quotient, test.quotient, opts...) {
+				t.Errorf("quotient: expected: %v, actual: %v", test.quotient, quotient)
+			}
+		})
+	}
+}
+
+func TestIntervalOutward(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		op       func(a, b Float64) Float64
+		op1, op2 Float64
+		min, max float64
+	}{
+		{
+			name: "add",
+			op:   Interval{Outward: true}.Add,
+			op1:  New(0.1, 0),
+			op2:  New(0.2, 0),
+			// 0.1+0.2 is not exactly representable.
+			min: 0.3,
+			max: 0.30000000000000004,
+		},
+		{
+			name: "sub",
+			op:   Interval{Outward: true}.Sub,
+			op1:  New(1, 0.1),
+			op2:  New(0.3, 0),
+			min:  0.6,
+			max:  0.8,
+		},
+		{
+			name: "mul",
+			op:   Interval{Outward: true}.Mul,
+			op1:  New(0.1, 0),
+			op2:  New(3, 0),
+			min:  0.3,
+			max:  0.30000000000000004,
+		},
+		{
+			name: "div",
+			op:   Interval{Outward: true}.Div,
+			op1:  New(1, 0),
+			op2:  New(3, 0),
+			min:  0.3333333333333333,
+			max:  0.33333333333333337,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.op(test.op1, test.op2)
+			if !(actual.Min() < test.min && test.max < actual.Max()) {
+				t.Errorf("expected strict enclosure of [%v, %v], actual: [%v, %v]",
+					test.min, test.max, actual.Min(), actual.Max())
+			}
+			if width := actual.Max() - actual.Min(); width > test.max-test.min+1e-14 {
+				t.Errorf("enclosure too wide: [%v, %v]", actual.Min(), actual.Max())
+			}
+		})
+	}
+}
+
+// Not parallel: changes the package-wide propagator.
+func TestSetPropagator(t *testing.T) {
+	prev := SetPropagator(Quadrature{})
+	defer SetPropagator(prev)
+	if _, ok := prev.(WorstCase); !ok {
+		t.Errorf("expected WorstCase to be the default, got: %T", prev)
+	}
+	expected := New(15, 5)
+	actual := Add(New(10, 3), New(5, 4))
+	if !cmp.Equal(actual, expected, opts...) {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+	if _, ok := CurrentPropagator().(Quadrature); !ok {
+		t.Errorf("expected Quadrature, got: %T", CurrentPropagator())
+	}
+}
+
+func TestGuard(t *testing.T) {
+	t.Parallel()
+	g := &Guard{}
+	if actual := g.Add(New(1, 0.5), New(2, 0.5)); !near(actual, New(3, 1)) {
+		t.Errorf("expected: 3±1, actual: %v", actual)
+	}
+	if err := g.Err(); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if actual := g.Mul(New(1e308, 0), New(10, 0)); !math.IsInf(actual.Value(), 1) {
+		t.Errorf("expected +Inf, actual: %v", actual)
+	}
+	g.Sub(New(math.NaN(), 0), New(1, 0))
+	err := g.Err()
+	if !errors.Is(err, ErrInvalid) {
+		t.Fatalf("expected ErrInvalid, got: %v", err)
+	}
+	if expected := "Mul(1e+308±0, 10±0): +Inf±NaN: delta is NaN: invalid approximate number"; err.Error() != expected {
+		t.Errorf("expected the first error: %q, actual: %q", expected, err)
+	}
+	g.Reset()
+	if err := g.Err(); err != nil {
+		t.Errorf("expected no error after Reset, got: %v", err)
+	}
+
+	q := &Guard{Propagator: Quadrature{}}
+	if actual := q.Add(New(1, 3), New(2, 4)); !near(actual, New(3, 5)) {
+		t.Errorf("expected: 3±5, actual: %v", actual)
+	}
+	q.Div(New(1, 0), New(0, 0))
+	if !errors.Is(q.Err(), ErrInvalid) {
+		t.Errorf("expected ErrInvalid, got: %v", q.Err())
+	}
+}
+
+func TestGuardPanic(t *testing.T) {
+	t.Parallel()
+	g := &Guard{Panic: true}
+	g.Add(New(1, 0), New(2, 0))
+	defer func() {
+		r := recover()
+		if err, ok := r.(error); !ok || !errors.Is(err, ErrInvalid) {
+			t.Errorf("expected panic with ErrInvalid, got: %v", r)
+		}
+		if g.Err() != nil {
+			t.Errorf("expected no recorded error, got: %v", g.Err())
+		}
+	}()
+	g.Div(New(1, 0), New(0, 0))
+	t.Errorf("expected panic")
+}
+
+// Not parallel: changes the package-wide propagator.
+func TestGuardChain(t *testing.T) {
+	g := &Guard{}
+	defer SetPropagator(SetPropagator(g))
+	x := New(2, 0.1)
+	r := Div(Add(x, x), Sub(x, x))
+	if err := g.Err(); err == nil {
+		t.Errorf("expected an error for %v", r)
+	}
+}
@@ -0,0 +1,39 @@
+package approx
+
+import (
+	"math/rand"
+	"testing"
+	"testing/quick"
+)
+
+func TestGenerate(t *testing.T) {
+	t.Parallel()
+	exact := 0
+	err := quick.Check(func(f Float64, g Float32) bool {
+		if f.IsExact() {
+			exact++
+		}
+		return f.Validate() == nil && g.Validate() == nil
+	}, &quick.Config{MaxCount: 1000, Rand: rand.New(rand.NewSource(1))})
+	if err != nil {
+		t.Error(err)
+	}
+	if exact == 0 || exact > 250 {
+		t.Errorf("unexpected number of exact numbers: %v", exact)
+	}
+}
+
+func TestGenerateProperties(t *testing.T) {
+	t.Parallel()
+	config := &quick.Config{MaxCount: 1000, Rand: rand.New(rand.NewSource(1))}
+	if err := quick.Check(func(a, b Float64) bool {
+		return WorstCase{}.Add(a, b).Delta() >= a.Delta()
+	}, config); err != nil {
+		t.Error(err)
+	}
+	if err := quick.Check(func(a Float64) bool {
+		return Neg(Neg(a)) == a
+	}, config); err != nil {
+		t.Error(err)
+	}
+}
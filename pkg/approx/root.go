@@ -0,0 +1,77 @@
+package approx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+)
+
+// ErrNoRoot is returned when a root is sought in an interval at whose ends
+// the function does not change sign.
+var ErrNoRoot = errors.New("no sign change in the interval")
+
+// FindRoot finds a root of f(x, params) for x between lo and hi, at whose
+// ends the values of f must have opposite signs.  Returns an error wrapping
+// ErrNoRoot otherwise.  f must propagate the uncertainties of params into its
+// result, e.g. by computing with the functions of this package.
+//
+// The root is found from the values of f.  Its uncertainty follows from the
+// implicit function theorem, based on the first order Taylor expansion of
+// f(r, params) = 0 around the root r:
+//   dr = d(f(r, params)) / |df/dx(r)|
+// where the derivative by x is computed numerically.
+//
+// Example:
+//     // The time at which the signal a*(1-exp(-t/tau)) crosses 1.
+//     cross, err := approx.FindRoot(func(t float64, p []approx.Float64) approx.Float64 {
+//         a, tau := p[0], p[1]
+//         return approx.Sub(approx.Mul(a, approx.Neg(approx.Expm1(
+//             approx.Div(approx.New(-t, 0), tau)))), approx.New(1, 0))
+//     }, 0, 10, approx.New(2, 0.1), approx.New(3, 0.2))
+//     // cross is 2.079441541679836±0.28862943610496544
+func FindRoot(f func(x float64, params []Float64) Float64, lo, hi float64, params ...Float64) (Float64, error) {
+	fx := func(x float64) float64 { return f(x, params).val }
+	r, err := bisect(fx, lo, hi)
+	if err != nil {
+		return Float64{}, fmt.Errorf("could not find root: %w", err)
+	}
+	fr := f(r, params)
+	if fr.delta == 0 {
+		return New(r, 0), nil
+	}
+	h := step(r)
+	df := (fx(r+h) - fx(r-h)) / (2 * h)
+	return New(r, fr.delta/math.Abs(df)).WithDistribution(fr.dist), nil
+}
+
+// bisect finds a root of f between lo and hi by bisection, to the full
+// float64 precision.
+func bisect(f func(float64) float64, lo, hi float64) (float64, error) {
+	flo, fhi := f(lo), f(hi)
+	switch {
+	case flo == 0:
+		return lo, nil
+	case fhi == 0:
+		return hi, nil
+	case !(flo*fhi < 0):
+		return 0, fmt.Errorf("f(%v)=%v, f(%v)=%v: %w", lo, flo, hi, fhi, ErrNoRoot)
+	}
+	for {
+		mid := lo + (hi-lo)/2
+		if mid == lo || mid == hi {
+			if math.Abs(flo) < math.Abs(fhi) {
+				return lo, nil
+			}
+			return hi, nil
+		}
+		fmid := f(mid)
+		switch {
+		case fmid == 0:
+			return mid, nil
+		case flo*fmid < 0:
+			hi, fhi = mid, fmid
+		default:
+			lo, flo = mid, fmid
+		}
+	}
+}
@@ -0,0 +1,72 @@
+package approx
+
+import (
+	"errors"
+	"math"
+	"testing"
+)
+
+func TestFindRoot(t *testing.T) {
+	t.Parallel()
+	// a*x + b
+	linear := func(x float64, p []Float64) Float64 {
+		return Add(p[0].Mul(x), p[1])
+	}
+	// a*(1-exp(-t/tau)) - 1
+	crossing := func(t float64, p []Float64) Float64 {
+		return Sub(Mul(p[0], Neg(Expm1(Div(New(-t, 0), p[1])))), New(1, 0))
+	}
+	tests := []struct {
+		name     string
+		f        func(float64, []Float64) Float64
+		lo, hi   float64
+		params   []Float64
+		expected Float64
+		err      error
+	}{
+		{
+			name:     "linear",
+			f:        linear,
+			lo:       0,
+			hi:       10,
+			params:   []Float64{New(2, 0.1), New(-4, 0.1)},
+			expected: New(2, 0.15),
+		},
+		{
+			name:     "exact",
+			f:        linear,
+			lo:       0,
+			hi:       10,
+			params:   []Float64{New(2, 0), New(-4, 0)},
+			expected: New(2, 0),
+		},
+		{
+			name:     "crossing",
+			f:        crossing,
+			lo:       0,
+			hi:       10,
+			params:   []Float64{New(2, 0.1), New(3, 0.2)},
+			expected: New(3*math.Ln2, 0.2*math.Ln2+0.1*1.5),
+		},
+		{
+			name:   "no root",
+			f:      linear,
+			lo:     3,
+			hi:     10,
+			params: []Float64{New(2, 0.1), New(-4, 0.1)},
+			err:    ErrNoRoot,
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual, err := FindRoot(test.f, test.lo, test.hi, test.params...)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if err == nil && (math.Abs(actual.val-test.expected.val) > 1e-12 || math.Abs(actual.delta-test.expected.delta) > 1e-8) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
@@ -0,0 +1,131 @@
+package approx
+
+import "math"
+
+// Rounding is a policy for rounding approximate numbers for display, as used
+// by String.  The delta is rounded to a decimal place chosen by the policy,
+// and the value is rounded to the same decimal place, which is how
+// measurements are reported.
+type Rounding interface {
+	// Place returns the decimal place to which to round the finite, positive
+	// delta, e.g. -2 for hundredths.
+	Place(delta float64) int
+}
+
+// SigFigs is a Rounding which keeps the given number of significant figures of
+// the delta, usually 1 or 2.
+//
+// Example:
+//     approx.SetRounding(approx.SigFigs(2))
+//     fmt.Println(approx.New(100, 1.9999999999988916)) // 100.0±2.0
+type SigFigs int
+
+// Place implements Rounding.
+func (n SigFigs) Place(delta float64) int {
+	if n < 1 {
+		n = 1
+	}
+	place, _ := significant(delta, int(n))
+	return place
+}
+
+// PDG is a Rounding which implements the rounding rule of the Particle Data
+// Group.  Based on the three leading digits of the delta:
+//
+//   100 to 354: the delta is rounded to two significant digits,
+//   355 to 949: the delta is rounded to one significant digit,
+//   950 to 999: the delta is rounded up to 1000, and kept to two significant
+//               digits.
+//
+// Example:
+//     approx.SetRounding(approx.PDG{})
+//     fmt.Println(approx.New(1.23456, 0.0123)) // 1.235±0.012
+//     fmt.Println(approx.New(1.23456, 0.0456)) // 1.23±0.05
+//     fmt.Println(approx.New(1.23456, 0.0987)) // 1.23±0.10
+type PDG struct{}
+
+// Place implements Rounding.
+func (PDG) Place(delta float64) int {
+	place, units := significant(delta, 3)
+	if units < 355 {
+		return place + 1
+	}
+	// Rounding 950 to 999 up to 1000 with two significant digits ends at the
+	// same place as rounding to one.
+	return place + 2
+}
+
+// rounding is used by String.  If nil, String prints full precision.
+var rounding Rounding
+
+// SetRounding makes r the Rounding used by String, and returns the previously
+// used Rounding so that it can be restored later.  With nil, which is the
+// default, String prints the value and the delta with full precision.
+//
+// SetRounding is meant to be called during program initialization.  It is
+// not safe to call it concurrently with formatting.
+func SetRounding(r Rounding) Rounding {
+	prev := rounding
+	rounding = r
+	return prev
+}
+
+// CurrentRounding returns the Rounding used by String.
+func CurrentRounding() Rounding {
+	return rounding
+}
+
+// Round rounds the delta of f up to sigDigits significant digits, and the
+// value to the same decimal place, so that the result prints without
+// insignificant digits, e.g. when serialized.  Rounding the delta up keeps the
+// interval of the result about as wide as the interval of f.  An exact f is
+// returned unchanged.
+//
+// Example:
+//     approx.Round(approx.New(100.0123, 1.9999999999988916), 2) // 100±2
+//     approx.Round(approx.New(1.23456, 0.0121), 2)              // 1.235±0.013
+func Round(f Float64, sigDigits int) Float64 {
+	if f.delta == 0 || !finite(f) {
+		return f
+	}
+	if sigDigits < 1 {
+		sigDigits = 1
+	}
+	place, _ := significant(f.delta, sigDigits)
+	units := toPlace(f.delta, place)
+	if r := math.Round(units); math.Abs(units-r) <= 1e-9*r {
+		// Do not round up because of the rounding error of the division.
+		units = r
+	}
+	f.delta = fromPlace(math.Ceil(units), place)
+	if v := toPlace(f.val, place); math.Abs(v) < 1<<53 {
+		// Otherwise, the value has no digits below the place to round.
+		f.val = fromPlace(math.Round(v), place)
+	}
+	return f
+}
+
+// toPlace returns x in units of the decimal place place.
+func toPlace(x float64, place int) float64 {
+	if place < -300 {
+		// The places of subnormal numbers are beyond the range of Pow10.
+		return toPlace(x*1e300, place+300)
+	}
+	if place < 0 {
+		return x * math.Pow10(-place)
+	}
+	return x / math.Pow10(place)
+}
+
+// fromPlace returns the units of the decimal place place as a number.  Dividing
+// by a power of ten, rather than multiplying by its inverse, gives the
+// float64 nearest to the decimal number.
+func fromPlace(units float64, place int) float64 {
+	if place < -300 {
+		return fromPlace(units, place+300) / 1e300
+	}
+	if place < 0 {
+		return units / math.Pow10(-place)
+	}
+	return units * math.Pow10(place)
+}
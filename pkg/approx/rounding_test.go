@@ -0,0 +1,117 @@
+package approx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSigFigs(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		n        SigFigs
+		delta    float64
+		expected int
+	}{
+		{n: 1, delta: 0.3, expected: -1},
+		{n: 2, delta: 0.3, expected: -2},
+		{n: 2, delta: 1.9999999999988916, expected: -1},
+		{n: 1, delta: 0.96, expected: 0},
+		{n: 2, delta: 1234, expected: 2},
+		{n: 0, delta: 0.05, expected: -2},
+		{n: 2, delta: 5e-324, expected: -325},
+		{n: 1, delta: 1e-320, expected: -320},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run("", func(t *testing.T) {
+			t.Parallel()
+			if actual := test.n.Place(test.delta); actual != test.expected {
+				t.Errorf("SigFigs(%v).Place(%v): expected: %v, actual: %v", test.n, test.delta, test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestPDG(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f        Float64
+		expected string
+	}{
+		{f: New(1.23456, 0.0123), expected: "1.235±0.012"},
+		{f: New(1.23456, 0.03544), expected: "1.235±0.035"},
+		{f: New(1.23456, 0.0355), expected: "1.23±0.04"},
+		{f: New(1.23456, 0.0456), expected: "1.23±0.05"},
+		{f: New(1.23456, 0.0949), expected: "1.23±0.09"},
+		{f: New(1.23456, 0.0987), expected: "1.23±0.10"},
+		{f: New(12345, 678), expected: "12300±700"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.expected, func(t *testing.T) {
+			t.Parallel()
+			val, delta := roundPlace(test.f, PDG{}.Place(test.f.delta))
+			if actual := val + "±" + delta; actual != test.expected {
+				t.Errorf("expected: %q, actual: %q", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestRound(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		f         Float64
+		sigDigits int
+		expected  Float64
+	}{
+		{f: New(100.0123, 1.9999999999988916), sigDigits: 2, expected: New(100, 2)},
+		{f: New(100.0123, 1.9999999999988916), sigDigits: 1, expected: New(100, 2)},
+		{f: New(1.23456, 0.0121), sigDigits: 2, expected: New(1.235, 0.013)},
+		{f: New(1.23456, 0.0121), sigDigits: 1, expected: New(1.23, 0.02)},
+		{f: New(1.23456, 1.1), sigDigits: 2, expected: New(1.2, 1.1)},
+		{f: New(-12345, 678), sigDigits: 1, expected: New(-12300, 700)},
+		{f: New(0.7, 0.3), sigDigits: 1, expected: New(0.7, 0.3)},
+		{f: New(4.2, 0), sigDigits: 2, expected: New(4.2, 0)},
+		{f: New(5e-324, 5e-324), sigDigits: 2, expected: New(5e-324, 5e-324)},
+		{f: New(1, 1e-320), sigDigits: 2, expected: New(1, 1e-320)},
+		{f: New(math.NaN(), 1), sigDigits: 2, expected: New(math.NaN(), 1)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.f.String(), func(t *testing.T) {
+			t.Parallel()
+			actual := Round(test.f, test.sigDigits)
+			if actual.String() != test.expected.String() {
+				t.Errorf("Round(%v, %v): expected: %v, actual: %v", test.f, test.sigDigits, test.expected, actual)
+			}
+		})
+	}
+}
+
+// Not parallel: changes the package-wide rounding.
+func TestSetRounding(t *testing.T) {
+	prev := SetRounding(SigFigs(2))
+	defer SetRounding(prev)
+	if prev != nil {
+		t.Errorf("expected full precision to be the default, got: %T", prev)
+	}
+	if _, ok := CurrentRounding().(SigFigs); !ok {
+		t.Errorf("expected SigFigs, got: %T", CurrentRounding())
+	}
+	tests := []struct {
+		f        Float64
+		expected string
+	}{
+		{f: New(100, 1.9999999999988916), expected: "100.0±2.0"},
+		{f: New(1.23456, 0.0123), expected: "1.235±0.012"},
+		{f: New(12345, 678), expected: "12350±680"},
+		{f: New(4.2, 0), expected: "4.2±0"},
+		{f: New(math.Inf(1), 1), expected: "+Inf±1"},
+	}
+	for _, test := range tests {
+		if actual := test.f.String(); actual != test.expected {
+			t.Errorf("expected: %q, actual: %q", test.expected, actual)
+		}
+	}
+}
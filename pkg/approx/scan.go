@@ -0,0 +1,65 @@
+package approx
+
+import (
+	"fmt"
+	"strings"
+	"unicode"
+)
+
+// Scan implements fmt.Scanner, so that approximate numbers can be read with
+// fmt.Sscan and friends, in any form accepted by Parse.
+//
+// Since spaces separate the scanned items, the value must be followed by the
+// separator of the delta without a space, e.g. "4.2±0.3", "4.2± 0.3" or
+// "4.2+/- 0.3", but not "4.2 ± 0.3", which scans as the exact number 4.2
+// followed by other input.
+//
+// Example:
+//     var f approx.Float64
+//     fmt.Sscan("4.2±0.3", &f) // f is 4.2±0.3
+func (f *Number[T]) Scan(state fmt.ScanState, verb rune) error {
+	switch verb {
+	case 'v', 's', 'e', 'E', 'f', 'F', 'g', 'G':
+	default:
+		return fmt.Errorf("could not scan approximate number with verb %%%c", verb)
+	}
+	state.SkipSpace()
+	tok, err := state.Token(false, notSpace)
+	if err != nil {
+		return fmt.Errorf("could not scan approximate number: %w", err)
+	}
+	s := string(tok)
+	if hasPlusMinus(s, strings.HasSuffix) {
+		skipBlanks(state)
+		delta, err := state.Token(false, notSpace)
+		if err != nil {
+			return fmt.Errorf("could not scan approximate number: %w", err)
+		}
+		s += string(delta)
+	}
+	v, err := Parse(s)
+	if err != nil {
+		return fmt.Errorf("could not scan approximate number: %w", err)
+	}
+	*f = Convert[T](v)
+	return nil
+}
+
+// notSpace returns true if r is not a space.
+func notSpace(r rune) bool {
+	return !unicode.IsSpace(r)
+}
+
+// skipBlanks skips the spaces, but not the newlines, in state.
+func skipBlanks(state fmt.ScanState) {
+	for {
+		r, _, err := state.ReadRune()
+		if err != nil {
+			return
+		}
+		if r == '\n' || !unicode.IsSpace(r) {
+			state.UnreadRune()
+			return
+		}
+	}
+}
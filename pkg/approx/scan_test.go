@@ -0,0 +1,70 @@
+package approx
+
+import (
+	"fmt"
+	"testing"
+)
+
+func TestScan(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		input    string
+		expected []Float64
+		err      bool
+	}{
+		{input: "4.2±0.3", expected: []Float64{New(4.2, 0.3)}},
+		{input: "  4.2±0.3  ", expected: []Float64{New(4.2, 0.3)}},
+		{input: "4.2± 0.3", expected: []Float64{New(4.2, 0.3)}},
+		{input: "4.2 ±0.3", expected: []Float64{New(4.2, 0), New(0, 0)}, err: true},
+		{input: "4.2", expected: []Float64{New(4.2, 0)}},
+		{input: "4.2+-0.3", expected: []Float64{New(4.2, 0.3)}},
+		{input: "4.2+/- 0.3", expected: []Float64{New(4.2, 0.3)}},
+		{input: "~50 ~1.20e3", expected: []Float64{New(50, 0.5), New(1200, 5)}},
+		{input: "4.2±0.3 5± 1", expected: []Float64{New(4.2, 0.3), New(5, 1)}},
+		{input: "1e3±2e1\n7", expected: []Float64{New(1000, 20), New(7, 0)}},
+		{input: "4.2±x", err: true},
+		{input: "4.2±\n0.3", err: true},
+		{input: "", err: true},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.input, func(t *testing.T) {
+			t.Parallel()
+			actual := make([]Float64, len(test.expected))
+			args := make([]interface{}, len(actual))
+			for i := range actual {
+				args[i] = &actual[i]
+			}
+			if len(args) == 0 {
+				args = append(args, new(Float64))
+			}
+			_, err := fmt.Sscan(test.input, args...)
+			if (err != nil) != test.err {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			for i := range test.expected {
+				if !near(actual[i], test.expected[i]) {
+					t.Errorf("expected: %v, actual: %v", test.expected, actual)
+				}
+			}
+		})
+	}
+}
+
+func TestScanln(t *testing.T) {
+	t.Parallel()
+	var a, b Float64
+	var c Float32
+	if n, err := fmt.Sscanln("1±0.5 2\n3", &a, &b, &c); n != 2 || err == nil {
+		t.Errorf("expected to stop at newline, scanned: %v, err: %v", n, err)
+	}
+	if _, err := fmt.Sscanf("x=1±0.5 y=0.1± 0.01", "x=%v y=%g", &a, &c); err != nil {
+		t.Fatalf("unexpected error: %v", err)
+	}
+	if !near(a, New(1, 0.5)) || c.Value() != float64(float32(0.1)) {
+		t.Errorf("expected: 1±0.5 and 0.1±0.01, actual: %v and %v", a, c)
+	}
+	if _, err := fmt.Sscanf("1", "%d", &a); err == nil {
+		t.Errorf("expected error for verb %%d")
+	}
+}
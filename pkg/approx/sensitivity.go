@@ -0,0 +1,60 @@
+package approx
+
+import "math"
+
+// Sensitivity describes how one input of a computation contributes to the
+// uncertainty of its result, see Sensitivities.
+type Sensitivity struct {
+	// Coefficient is the partial derivative of the result with respect to
+	// the input.
+	Coefficient float64
+	// Contribution is the part of the delta of the result that is due to
+	// the input, which is |Coefficient|*delta of the input.
+	Contribution float64
+	// Percent is the share of Contribution in the sum of the contributions
+	// of all inputs, in percent.
+	Percent float64
+}
+
+// Sensitivities computes f(args...) and reports, for each of args in turn,
+// how much it contributes to the delta of the result.  This shows which
+// input dominates the uncertainty, and is worth measuring more precisely.
+//
+// The sensitivity coefficients are the partial derivatives of f by args,
+// computed numerically from the values of f at exact arguments.  Based on
+// first order Taylor expansion of f around args, the contributions add up to
+// the delta of the result in the worst case manner:
+//   d(f) = sum(|df/dx_i| * dx_i)
+//
+// Example:
+//     // The area of a 2±0.01 by 1±0.02 rectangle.
+//     s := approx.Sensitivities(func(x ...approx.Float64) approx.Float64 {
+//         return approx.Mul(x[0], x[1])
+//     }, approx.New(2, 0.01), approx.New(1, 0.02))
+//     // s[0] is {1 0.01 20}, s[1] is {2 0.04 80}: improve the width first.
+func Sensitivities(f func(...Float64) Float64, args ...Float64) []Sensitivity {
+	x := make([]Float64, len(args))
+	for i, a := range args {
+		x[i] = New(a.val, 0)
+	}
+	s := make([]Sensitivity, len(args))
+	var sum float64
+	for i, a := range args {
+		h := step(a.val)
+		x[i] = New(a.val+h, 0)
+		fmax := f(x...).val
+		x[i] = New(a.val-h, 0)
+		fmin := f(x...).val
+		x[i] = New(a.val, 0)
+		c := (fmax - fmin) / (2 * h)
+		s[i] = Sensitivity{Coefficient: c, Contribution: math.Abs(c * a.delta)}
+		sum += s[i].Contribution
+	}
+	if sum == 0 {
+		return s
+	}
+	for i := range s {
+		s[i].Percent = 100 * s[i].Contribution / sum
+	}
+	return s
+}
@@ -0,0 +1,80 @@
+package approx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestSensitivities(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		f        func(...Float64) Float64
+		args     []Float64
+		expected []Sensitivity
+	}{
+		{
+			name: "product",
+			f:    func(x ...Float64) Float64 { return Mul(x[0], x[1]) },
+			args: []Float64{New(2, 0.01), New(1, 0.02)},
+			expected: []Sensitivity{
+				{Coefficient: 1, Contribution: 0.01, Percent: 20},
+				{Coefficient: 2, Contribution: 0.04, Percent: 80},
+			},
+		},
+		{
+			name: "difference",
+			f:    func(x ...Float64) Float64 { return Sub(x[0], x[1]) },
+			args: []Float64{New(5, 0.3), New(3, 0.1)},
+			expected: []Sensitivity{
+				{Coefficient: 1, Contribution: 0.3, Percent: 75},
+				{Coefficient: -1, Contribution: 0.1, Percent: 25},
+			},
+		},
+		{
+			name: "exact",
+			f:    func(x ...Float64) Float64 { return Add(x[0], x[1]) },
+			args: []Float64{New(5, 0), New(3, 0)},
+			expected: []Sensitivity{
+				{Coefficient: 1},
+				{Coefficient: 1},
+			},
+		},
+		{
+			name:     "no inputs",
+			f:        func(x ...Float64) Float64 { return New(1, 0.5) },
+			expected: []Sensitivity{},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			actual := Sensitivities(test.f, test.args...)
+			if len(actual) != len(test.expected) {
+				t.Fatalf("expected: %v, actual: %v", test.expected, actual)
+			}
+			for i, e := range test.expected {
+				a := actual[i]
+				if math.Abs(a.Coefficient-e.Coefficient) > 1e-9 ||
+					math.Abs(a.Contribution-e.Contribution) > 1e-9 ||
+					math.Abs(a.Percent-e.Percent) > 1e-6 {
+					t.Errorf("input %v: expected: %+v, actual: %+v", i, e, a)
+				}
+			}
+		})
+	}
+}
+
+func TestSensitivitiesSum(t *testing.T) {
+	t.Parallel()
+	args := []Float64{New(2, 0.1), New(3, 0.2)}
+	f := func(x ...Float64) Float64 { return Div(Exp(x[0]), x[1]) }
+	var sum float64
+	for _, s := range Sensitivities(f, args...) {
+		sum += s.Contribution
+	}
+	if expected := f(args...).delta; math.Abs(sum-expected) > 1e-6*expected {
+		t.Errorf("expected: %v, actual: %v", expected, sum)
+	}
+}
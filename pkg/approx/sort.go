@@ -0,0 +1,108 @@
+package approx
+
+import (
+	"math"
+	"sort"
+)
+
+// Sort sorts fs in increasing order of their values.  Numbers with equal
+// values are ordered by increasing delta, so that the more precise one comes
+// first.  Numbers with a NaN value come last.  The sort is stable, so that
+// numbers with equal values and deltas keep their order.
+//
+// The order is consistent with Lt: if a is definitely less than b, a comes
+// before b.  Use TopoSort to find which of the numbers are not definitely
+// ordered.
+func Sort(fs []Float64) {
+	sort.SliceStable(fs, func(i, j int) bool {
+		return lessBy(fs[i].val, fs[i].delta, fs[j].val, fs[j].delta)
+	})
+}
+
+// SortByMin sorts fs in increasing order of the lower bounds of their
+// intervals, such as when scheduling by the earliest possible time.  Numbers
+// with equal lower bounds are ordered by increasing upper bound.  Numbers with
+// a NaN bound come last.  The sort is stable.
+func SortByMin(fs []Float64) {
+	sort.SliceStable(fs, func(i, j int) bool {
+		return lessBy(fs[i].Min(), fs[i].Max(), fs[j].Min(), fs[j].Max())
+	})
+}
+
+// lessBy compares the pairs (x1, y1) and (x2, y2) lexicographically, with NaN
+// after all other numbers.
+func lessBy(x1, y1, x2, y2 float64) bool {
+	if x1 != x2 {
+		return lessNaN(x1, x2)
+	}
+	return lessNaN(y1, y2)
+}
+
+// lessNaN returns true if x is less than y, with NaN after all other numbers.
+func lessNaN(x, y float64) bool {
+	if math.IsNaN(x) {
+		return false
+	}
+	return x < y || math.IsNaN(y)
+}
+
+// TopoSort ranks fs by the partial order of Lt, in which only the numbers
+// whose intervals do not overlap are ordered.  It returns groups of numbers,
+// such that each number in a group is definitely greater than some number in
+// the previous group, and no two numbers in a group are definitely ordered.
+// Numbers in the same group are therefore tied in rank.  Each group is sorted
+// as by Sort.  A number with a NaN value or delta is ordered with no other
+// number, and is in the first group.
+//
+// Example, ranking benchmark results:
+//     approx.TopoSort([]approx.Float64{
+//         approx.New(12, 1), approx.New(10, 1), approx.New(13, 0.5), approx.New(20, 2),
+//     })
+//     // [[10±1 12±1] [13±0.5] [20±2]]
+func TopoSort(fs []Float64) [][]Float64 {
+	sorted := append([]Float64(nil), fs...)
+	Sort(sorted)
+	// rank[i] is the length of the longest chain of numbers definitely
+	// less than sorted[i].  Numbers definitely less than sorted[i] come
+	// before it in sorted.
+	rank := make([]int, len(sorted))
+	var groups [][]Float64
+	for i, f := range sorted {
+		for j, g := range sorted[:i] {
+			if g.Lt(f) && rank[j]+1 > rank[i] {
+				rank[i] = rank[j] + 1
+			}
+		}
+		if rank[i] == len(groups) {
+			groups = append(groups, nil)
+		}
+	}
+	for i, f := range sorted {
+		groups[rank[i]] = append(groups[rank[i]], f)
+	}
+	return groups
+}
+
+// MaxOf returns the largest of fs, see Max.  The result is NaN if fs is
+// empty.
+func MaxOf(fs []Float64) Float64 {
+	return fold(fs, Max)
+}
+
+// MinOf returns the smallest of fs, see Min.  The result is NaN if fs is
+// empty.
+func MinOf(fs []Float64) Float64 {
+	return fold(fs, Min)
+}
+
+// fold combines fs from left to right with fx.
+func fold(fs []Float64, fx func(a, b Float64) Float64) Float64 {
+	if len(fs) == 0 {
+		return New(math.NaN(), 0)
+	}
+	r := fs[0]
+	for _, f := range fs[1:] {
+		r = fx(r, f)
+	}
+	return r
+}
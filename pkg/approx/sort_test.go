@@ -0,0 +1,108 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSort(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		fs       []Float64
+		expected string
+	}{
+		{name: "empty", fs: []Float64{}, expected: "[]"},
+		{name: "values", fs: []Float64{New(3, 1), New(1, 5), New(2, 0)}, expected: "[1±5 2±0 3±1]"},
+		{name: "ties", fs: []Float64{New(1, 0.5), New(1, 0.1), New(0, 9)}, expected: "[0±9 1±0.1 1±0.5]"},
+		{name: "NaN", fs: []Float64{New(math.NaN(), 0), New(2, 0), New(1, math.NaN())}, expected: "[1±NaN 2±0 NaN±0]"},
+		{name: "infinite", fs: []Float64{New(math.Inf(1), 0), New(math.Inf(-1), 0), New(0, 0)}, expected: "[-Inf±0 0±0 +Inf±0]"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			Sort(test.fs)
+			if actual := fmt.Sprint(test.fs); actual != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestSortByMin(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		fs       []Float64
+		expected string
+	}{
+		{name: "bounds", fs: []Float64{New(3, 1), New(1, 5), New(2, 0)}, expected: "[1±5 2±0 3±1]"},
+		{name: "by min", fs: []Float64{New(3, 3), New(1, 0.5)}, expected: "[3±3 1±0.5]"},
+		{name: "ties", fs: []Float64{New(2, 1), New(1.5, 0.5)}, expected: "[1.5±0.5 2±1]"},
+		{name: "NaN", fs: []Float64{New(1, math.NaN()), New(2, 0)}, expected: "[2±0 1±NaN]"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			SortByMin(test.fs)
+			if actual := fmt.Sprint(test.fs); actual != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTopoSort(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		fs       []Float64
+		expected string
+	}{
+		{name: "empty", expected: "[]"},
+		{name: "benchmarks", fs: []Float64{New(12, 1), New(10, 1), New(13, 0.5), New(20, 2)}, expected: "[[10±1 12±1] [13±0.5] [20±2]]"},
+		{name: "all tied", fs: []Float64{New(1, 10), New(0, 1), New(1.5, 1)}, expected: "[[0±1 1±10 1.5±1]]"},
+		{name: "chain", fs: []Float64{New(3, 0), New(2, 0), New(1, 0)}, expected: "[[1±0] [2±0] [3±0]]"},
+		{name: "longest chain", fs: []Float64{New(1, 0), New(2, 0), New(2, 5), New(10, 2)}, expected: "[[1±0 2±5] [2±0] [10±2]]"},
+		{name: "NaN", fs: []Float64{New(2, 0), New(math.NaN(), 0), New(1, 0)}, expected: "[[1±0 NaN±0] [2±0]]"},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := fmt.Sprint(TopoSort(test.fs)); actual != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestMaxOfMinOf(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		fs       []Float64
+		max, min Float64
+	}{
+		{fs: []Float64{New(1, 0.5), New(5, 1), New(3, 0)}, max: New(5, 1), min: New(1, 0.5)},
+		{fs: []Float64{New(2, 2), New(2, 1)}, max: New(2.5, 1.5), min: New(1.5, 1.5)},
+		{fs: []Float64{New(7, 1)}, max: New(7, 1), min: New(7, 1)},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprint(test.fs), func(t *testing.T) {
+			t.Parallel()
+			if actual := MaxOf(test.fs); !near(actual, test.max) {
+				t.Errorf("MaxOf: expected: %v, actual: %v", test.max, actual)
+			}
+			if actual := MinOf(test.fs); !near(actual, test.min) {
+				t.Errorf("MinOf: expected: %v, actual: %v", test.min, actual)
+			}
+		})
+	}
+	if !MaxOf(nil).IsNaN() || !MinOf(nil).IsNaN() {
+		t.Errorf("expected NaN for empty slices")
+	}
+}
@@ -0,0 +1,94 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+)
+
+func init() {
+	RegisterDerivative(math.Erf, derivErf)
+	RegisterDerivative(math.Erfc, func(x float64) float64 { return -derivErf(x) })
+	RegisterDerivative(math.Gamma, func(x float64) float64 { return math.Gamma(x) * digamma(x) })
+	RegisterDerivative(LgammaFunc, digamma)
+}
+
+// Erf computes the error function of f.
+//
+// Based on first order Taylor expansion around x:
+//   erf(x+dx) = erf(x) + 2/sqrt(pi)*e^(-x^2)*dx
+func Erf(f Float64) Float64 {
+	return f.chain(math.Erf(f.val), derivErf(f.val))
+}
+
+// Erfc computes the complementary error function of f, 1-Erf(f).  It is more
+// accurate than 1-Erf(f) for large values of f, see math.Erfc.
+func Erfc(f Float64) Float64 {
+	return f.chain(math.Erfc(f.val), -derivErf(f.val))
+}
+
+// Gamma computes the gamma function of f.
+//
+// Based on first order Taylor expansion around x:
+//   gamma(x+dx) = gamma(x) + gamma(x)*digamma(x)*dx
+//
+// Returns an error wrapping ErrDomain if the interval of f contains a pole of
+// the gamma function, that is zero or a negative integer.
+func Gamma(f Float64) (Float64, error) {
+	if gammaPole(f) {
+		return Float64{}, fmt.Errorf("could not compute gamma function of %v: %w", f, ErrDomain)
+	}
+	g := math.Gamma(f.val)
+	return f.chain(g, g*digamma(f.val)), nil
+}
+
+// Lgamma computes the natural logarithm of the absolute value of the gamma
+// function of f.  It does not overflow for large values of f, where Gamma
+// does.
+//
+// Based on first order Taylor expansion around x:
+//   lgamma(x+dx) = lgamma(x) + digamma(x)*dx
+//
+// Returns an error wrapping ErrDomain if the interval of f contains a pole of
+// the gamma function, that is zero or a negative integer.
+func Lgamma(f Float64) (Float64, error) {
+	if gammaPole(f) {
+		return Float64{}, fmt.Errorf("could not compute log gamma function of %v: %w", f, ErrDomain)
+	}
+	return f.chain(LgammaFunc(f.val), digamma(f.val)), nil
+}
+
+// LgammaFunc returns the natural logarithm of the absolute value of the gamma
+// function of x.  Unlike math.Lgamma, it is a function of one variable, which
+// can be applied to approximate numbers, with its registered derivative:
+//
+//     approx.New(200, 1).ApplyAuto(approx.LgammaFunc) // as Lgamma
+func LgammaFunc(x float64) float64 {
+	lg, _ := math.Lgamma(x)
+	return lg
+}
+
+// derivErf is the derivative of math.Erf.
+func derivErf(x float64) float64 {
+	return 2 / math.SqrtPi * math.Exp(-x*x)
+}
+
+// gammaPole returns true if the interval of f contains zero or a negative
+// integer.
+func gammaPole(f Float64) bool {
+	return f.Min() <= 0 && containsPoint(f.Min(), math.Min(f.Max(), 0), 0, 1)
+}
+
+// digamma computes the logarithmic derivative of the gamma function,
+// gamma'(x)/gamma(x).
+//
+// Uses the recurrence digamma(x) = digamma(x+1) - 1/x to shift x to where the
+// asymptotic expansion is accurate.
+func digamma(x float64) float64 {
+	var r float64
+	for ; x < 10; x++ {
+		r -= 1 / x
+	}
+	x2 := 1 / (x * x)
+	return r + math.Log(x) - 0.5/x -
+		x2*(1.0/12-x2*(1.0/120-x2*(1.0/252-x2*(1.0/240-x2*(1.0/132-x2*(691.0/32760))))))
+}
@@ -0,0 +1,67 @@
+package approx
+
+import (
+	"errors"
+	"fmt"
+	"math"
+	"testing"
+)
+
+func TestSpecial(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		f        func(Float64) (Float64, error)
+		input    Float64
+		expected Float64
+		err      error
+	}{
+		{name: "Erf", f: noError(Erf), input: New(0, 0.1), expected: New(0, 0.11283791670955126)},
+		{name: "Erfc", f: noError(Erfc), input: New(0, 0.1), expected: New(1, 0.11283791670955126)},
+		{name: "Erf", f: noError(Erf), input: New(1, 0.1).WithDistribution(Uniform),
+			expected: New(math.Erf(1), 0.041510749742059476).WithDistribution(Uniform)},
+		{name: "Gamma", f: Gamma, input: New(5, 0.1), expected: New(24, 3.614682404236398)},
+		{name: "Gamma", f: Gamma, input: New(-1.5, 0.4), expected: New(2.3632718012073544, 0.6647001042674385)},
+		{name: "Gamma", f: Gamma, input: New(-1.5, 0.6), err: ErrDomain},
+		{name: "Gamma", f: Gamma, input: New(0.5, 0.5), err: ErrDomain},
+		{name: "Lgamma", f: Lgamma, input: New(1, 0.1), expected: New(0, 0.05772156649015329)},
+		{name: "Lgamma", f: Lgamma, input: New(200, 1), expected: New(857.9336698258575, 5.295815283219911)},
+		{name: "Lgamma", f: Lgamma, input: New(-3, 0.1), err: ErrDomain},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(fmt.Sprintf("%v(%v)", test.name, test.input), func(t *testing.T) {
+			actual, err := test.f(test.input)
+			if !errors.Is(err, test.err) {
+				t.Fatalf("expected error: %v, actual: %v", test.err, err)
+			}
+			if err == nil && !near(actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestDigamma(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		x, expected float64
+	}{
+		{x: 1, expected: -0.5772156649015329},
+		{x: 0.5, expected: -1.9635100260214235},
+		{x: 10, expected: 2.251752589066721},
+		{x: -0.5, expected: 0.03648997397857652},
+	}
+	for _, test := range tests {
+		if actual := digamma(test.x); math.Abs(actual-test.expected) > 1e-12 {
+			t.Errorf("digamma(%v): expected: %v, actual: %v", test.x, test.expected, actual)
+		}
+	}
+}
+
+// noError adapts fx to the signature of functions which may return an error.
+func noError(fx func(Float64) Float64) func(Float64) (Float64, error) {
+	return func(f Float64) (Float64, error) {
+		return fx(f), nil
+	}
+}
@@ -0,0 +1,181 @@
+package approx
+
+import (
+	"fmt"
+	"reflect"
+	"runtime"
+	"strings"
+)
+
+// Traced is an approximate number which remembers the operations that it
+// was computed by, so that one can audit how its uncertainty was obtained.
+//
+// The arithmetic is the same as that of Float64, recording the operations
+// is opt-in by computing with Traced instead.
+//
+// Example:
+//     l := approx.TraceNamed("length", approx.New(2, 0.01))
+//     w := approx.TraceNamed("width", approx.New(1, 0.02))
+//     fmt.Print(l.Mul(w).Explain())
+//     // #0 = 2±0.01 (length)
+//     // #1 = 1±0.02 (width)
+//     // #2 = mul(#0, #1) = 2±0.05
+type Traced struct {
+	n *node
+}
+
+// node is a single step of a computation.
+type node struct {
+	// op is the name of the operation, empty for inputs.
+	op string
+	// name is the name of an input, if any.
+	name string
+	args []*node
+	// eval computes the step from the results of args.  Nil for inputs.
+	eval func(...Float64) Float64
+	res  Float64
+}
+
+// Step is a single step in the History of a Traced number.
+type Step struct {
+	// Op is the name of the operation, such as "add", or empty for an input.
+	Op string
+	// Name is the name of an input, as given to TraceNamed.
+	Name string
+	// Args are the indices of the operands of Op in the history.
+	Args []int
+	// Result is the result of the step.
+	Result Float64
+}
+
+// Trace creates a Traced number from the input f.
+func Trace(f Float64) Traced {
+	return TraceNamed("", f)
+}
+
+// TraceNamed creates a Traced number from the input f, which is the
+// quantity called name.
+func TraceNamed(name string, f Float64) Traced {
+	return Traced{n: &node{name: name, res: f}}
+}
+
+// TraceOp applies the function f, called op, to the traced numbers args and
+// records the application.  This allows tracing functions other than the
+// arithmetic operations.
+//
+// Example:
+//     h := approx.TraceOp("hypot", func(x ...approx.Float64) approx.Float64 {
+//         return approx.Hypot(x[0], x[1])
+//     }, a, b)
+func TraceOp(op string, f func(...Float64) Float64, args ...Traced) Traced {
+	n := &node{op: op, eval: f, args: make([]*node, len(args))}
+	vals := make([]Float64, len(args))
+	for i, a := range args {
+		n.args[i] = a.node()
+		vals[i] = n.args[i].res
+	}
+	n.res = f(vals...)
+	return Traced{n: n}
+}
+
+// node returns the node of t.  The zero Traced is the exact input 0.
+func (t Traced) node() *node {
+	if t.n == nil {
+		return &node{}
+	}
+	return t.n
+}
+
+// Float64 returns the result of t.  The returned value forgets the history
+// of t.
+func (t Traced) Float64() Float64 {
+	return t.node().res
+}
+
+// String implements Stringer.
+func (t Traced) String() string {
+	return t.Float64().String()
+}
+
+// Add computes t+u, see Add.
+func (t Traced) Add(u Traced) Traced {
+	return TraceOp("add", func(x ...Float64) Float64 { return Add(x[0], x[1]) }, t, u)
+}
+
+// Sub computes t-u, see Sub.
+func (t Traced) Sub(u Traced) Traced {
+	return TraceOp("sub", func(x ...Float64) Float64 { return Sub(x[0], x[1]) }, t, u)
+}
+
+// Mul computes t*u, see Mul.
+func (t Traced) Mul(u Traced) Traced {
+	return TraceOp("mul", func(x ...Float64) Float64 { return Mul(x[0], x[1]) }, t, u)
+}
+
+// Div computes t/u, see Div.
+func (t Traced) Div(u Traced) Traced {
+	return TraceOp("div", func(x ...Float64) Float64 { return Div(x[0], x[1]) }, t, u)
+}
+
+// Scale computes c*t for an exact number c, which is recorded as an input.
+func (t Traced) Scale(c float64) Traced {
+	return t.Mul(Trace(New(c, 0)))
+}
+
+// Apply applies the function fx to t, see Float64.Apply for details.  The
+// step is named after fx, e.g. "math.Sin".
+func (t Traced) Apply(fx func(float64) float64, eps float64) Traced {
+	return TraceOp(funcName(fx), func(x ...Float64) Float64 { return x[0].Apply(fx, eps) }, t)
+}
+
+// funcName returns the name of the function fx.
+func funcName(fx func(float64) float64) string {
+	if f := runtime.FuncForPC(reflect.ValueOf(fx).Pointer()); f != nil {
+		return f.Name()
+	}
+	return "apply"
+}
+
+// History returns the steps of the computation of t, with the operands of
+// each step before the step itself, and the result of t last.  A
+// subexpression used several times appears once.
+func (t Traced) History() []Step {
+	var steps []Step
+	index := make(map[*node]int)
+	var visit func(n *node) int
+	visit = func(n *node) int {
+		if i, ok := index[n]; ok {
+			return i
+		}
+		s := Step{Op: n.op, Name: n.name, Result: n.res}
+		for _, a := range n.args {
+			s.Args = append(s.Args, visit(a))
+		}
+		index[n] = len(steps)
+		steps = append(steps, s)
+		return index[n]
+	}
+	visit(t.node())
+	return steps
+}
+
+// Explain returns the History of t as text, one line per step.
+func (t Traced) Explain() string {
+	var b strings.Builder
+	for i, s := range t.History() {
+		fmt.Fprintf(&b, "#%v = ", i)
+		if s.Op != "" {
+			args := make([]string, len(s.Args))
+			for j, a := range s.Args {
+				args[j] = fmt.Sprintf("#%v", a)
+			}
+			fmt.Fprintf(&b, "%v(%v) = ", s.Op, strings.Join(args, ", "))
+		}
+		fmt.Fprintf(&b, "%v", s.Result)
+		if s.Name != "" {
+			fmt.Fprintf(&b, " (%v)", s.Name)
+		}
+		b.WriteString("\n")
+	}
+	return b.String()
+}
@@ -0,0 +1,86 @@
+package approx
+
+import (
+	"math"
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestTracedExplain(t *testing.T) {
+	t.Parallel()
+	l := TraceNamed("length", New(2, 0.01))
+	w := TraceNamed("width", New(1, 0.02))
+	tests := []struct {
+		name     string
+		actual   Traced
+		expected string
+	}{
+		{
+			name:     "input",
+			actual:   l,
+			expected: "#0 = 2±0.01 (length)\n",
+		},
+		{
+			name:   "product",
+			actual: l.Mul(w),
+			expected: "" +
+				"#0 = 2±0.01 (length)\n" +
+				"#1 = 1±0.02 (width)\n" +
+				"#2 = mul(#0, #1) = 2±0.05\n",
+		},
+		{
+			name:   "shared",
+			actual: l.Add(w).Scale(2).Div(l.Add(w)),
+			expected: "" +
+				"#0 = 2±0.01 (length)\n" +
+				"#1 = 1±0.02 (width)\n" +
+				"#2 = add(#0, #1) = 3±0.03\n" +
+				"#3 = 2±0\n" +
+				"#4 = mul(#2, #3) = 6±0.06\n" +
+				"#5 = add(#0, #1) = 3±0.03\n" +
+				"#6 = div(#4, #5) = 2±0.04\n",
+		},
+		{
+			name:   "apply",
+			actual: Trace(New(0, 0.1)).Apply(math.Sin, 0),
+			expected: "" +
+				"#0 = 0±0.1\n" +
+				"#1 = math.Sin(#0) = 0±0.1\n",
+		},
+		{
+			name:     "zero",
+			actual:   Traced{},
+			expected: "#0 = 0±0\n",
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			t.Parallel()
+			if actual := test.actual.Explain(); actual != test.expected {
+				t.Errorf("expected:\n%v\nactual:\n%v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestTracedHistory(t *testing.T) {
+	t.Parallel()
+	x := Trace(New(3, 0.5))
+	y := TraceNamed("y", New(4, 1))
+	h := TraceOp("hypot", func(a ...Float64) Float64 { return Hypot(a[0], a[1]) }, x, y)
+	d := h.Sub(x)
+	expected := []Step{
+		{Result: New(3, 0.5)},
+		{Name: "y", Result: New(4, 1)},
+		{Op: "hypot", Args: []int{0, 1}, Result: Hypot(New(3, 0.5), New(4, 1))},
+		{Op: "sub", Args: []int{2, 0}, Result: Sub(Hypot(New(3, 0.5), New(4, 1)), New(3, 0.5))},
+	}
+	if actual := d.History(); !cmp.Equal(actual, expected, opts...) {
+		t.Errorf("diff: %v", cmp.Diff(expected, actual, opts...))
+	}
+	if actual := d.Float64(); !cmp.Equal(actual, expected[3].Result, opts...) {
+		t.Errorf("expected: %v, actual: %v", expected[3].Result, actual)
+	}
+}
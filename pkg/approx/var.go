@@ -0,0 +1,215 @@
+package approx
+
+import (
+	"math"
+	"sync/atomic"
+)
+
+// Var is an approximate number which remembers the independent measurements
+// it was computed from.
+//
+// Float64 treats every operand as an independent measurement, so using the
+// same measurement twice in a formula overstates the uncertainty of the
+// result: Sub(x, x) is not exact.  A Var instead records the first order
+// sensitivity of its value to each independent source, so that
+//
+//     x := approx.NewVar(approx.New(10, 1))
+//     x.Sub(x) // is exactly 0±0
+//     x.Add(x) // is 20±2, same as x.Scale(2)
+//
+// Sources are identified by the NewVar call that created them, not by their
+// value.  Two calls to NewVar with the same Float64 create two independent
+// sources.
+type Var struct {
+	val float64
+	// terms is sorted by source id, and contains no zero coefficients.
+	terms []term
+}
+
+// source is an independent measurement.
+type source struct {
+	id uint64
+	// name is the name of the measured quantity, if any.
+	name  string
+	val   float64
+	delta float64
+	dist  Distribution
+	// std is the standard uncertainty of the measurement.
+	std float64
+}
+
+// term is the contribution of a single source to a Var.
+type term struct {
+	src *source
+	// coef is the partial derivative of the value with respect to src.
+	coef float64
+}
+
+// lastSourceID is the id of the most recently created source.  Accessed
+// atomically.
+var lastSourceID uint64
+
+// NewVar creates a Var from f, which is taken to be a new independent
+// measurement.
+func NewVar(f Float64) Var {
+	return NewNamedVar("", f)
+}
+
+// NewNamedVar creates a Var from f, which is taken to be a new independent
+// measurement of the quantity called name.  The name identifies the
+// measurement in the uncertainty budget, see Var.Budget.
+func NewNamedVar(name string, f Float64) Var {
+	if f.delta == 0 {
+		return Var{val: f.val}
+	}
+	src := &source{
+		id:    atomic.AddUint64(&lastSourceID, 1),
+		name:  name,
+		val:   f.val,
+		delta: f.delta,
+		dist:  f.dist,
+		std:   f.StdDev(),
+	}
+	return Var{val: f.val, terms: []term{{src: src, coef: 1}}}
+}
+
+// Value returns the value at the center of v's interval.
+func (v Var) Value() float64 {
+	return v.val
+}
+
+// Delta returns the worst case uncertainty of v, which is the sum of the
+// contributions of all independent sources of v.
+func (v Var) Delta() float64 {
+	var d float64
+	for _, t := range v.terms {
+		d += math.Abs(t.coef * t.src.delta)
+	}
+	return d
+}
+
+// StdDev returns the standard uncertainty of v, obtained by adding the
+// standard uncertainties of all independent sources in quadrature.
+func (v Var) StdDev() float64 {
+	var d float64
+	for _, t := range v.terms {
+		d = math.Hypot(d, t.coef*t.src.std)
+	}
+	return d
+}
+
+// Float64 converts v into a Float64 with worst case uncertainty.  The
+// returned value forgets the sources of v.
+func (v Var) Float64() Float64 {
+	return New(v.val, v.Delta())
+}
+
+// String implements Stringer.
+func (v Var) String() string {
+	return v.Float64().String()
+}
+
+// Add computes v+w.
+func (v Var) Add(w Var) Var {
+	return Var{val: v.val + w.val, terms: combine(1, v.terms, 1, w.terms)}
+}
+
+// Sub computes v-w.
+func (v Var) Sub(w Var) Var {
+	return Var{val: v.val - w.val, terms: combine(1, v.terms, -1, w.terms)}
+}
+
+// Mul computes v*w.
+func (v Var) Mul(w Var) Var {
+	return Var{val: v.val * w.val, terms: combine(w.val, v.terms, v.val, w.terms)}
+}
+
+// Div computes v/w.
+func (v Var) Div(w Var) Var {
+	q := v.val / w.val
+	return Var{val: q, terms: combine(1/w.val, v.terms, -q/w.val, w.terms)}
+}
+
+// Scale computes c*v for an exact number c.
+func (v Var) Scale(c float64) Var {
+	return Var{val: c * v.val, terms: combine(c, v.terms, 0, nil)}
+}
+
+// Apply applies the function fx to v, see Float64.Apply for details.
+func (v Var) Apply(fx func(float64) float64, eps float64) Var {
+	dfx := derivative(fx, v.val, eps)
+	return Var{val: fx(v.val), terms: combine(dfx, v.terms, 0, nil)}
+}
+
+// ApplyD applies the function fx, whose derivative is dfx, to v.  See
+// Float64.ApplyD.
+func (v Var) ApplyD(fx, dfx func(float64) float64) Var {
+	return Var{val: fx(v.val), terms: combine(dfx(v.val), v.terms, 0, nil)}
+}
+
+// Covariance returns the covariance of v and w, computed from the standard
+// uncertainties of their sources.
+func Covariance(v, w Var) float64 {
+	var c float64
+	i, j := 0, 0
+	for i < len(v.terms) && j < len(w.terms) {
+		ti, tj := v.terms[i], w.terms[j]
+		switch {
+		case ti.src.id < tj.src.id:
+			i++
+		case ti.src.id > tj.src.id:
+			j++
+		default:
+			c += ti.coef * tj.coef * ti.src.std * ti.src.std
+			i++
+			j++
+		}
+	}
+	return c
+}
+
+// Correlation returns the correlation coefficient of v and w, a number
+// between -1 and 1.  The correlation of a Var with no uncertainty is 0.
+func Correlation(v, w Var) float64 {
+	sv, sw := v.StdDev(), w.StdDev()
+	if sv == 0 || sw == 0 {
+		return 0
+	}
+	return Covariance(v, w) / (sv * sw)
+}
+
+// combine computes the terms of the linear combination ca*a+cb*b, dropping
+// the sources which no longer contribute.
+func combine(ca float64, a []term, cb float64, b []term) []term {
+	r := make([]term, 0, len(a)+len(b))
+	add := func(t term, c float64) {
+		if t.coef*c != 0 {
+			r = append(r, term{src: t.src, coef: t.coef * c})
+		}
+	}
+	i, j := 0, 0
+	for i < len(a) && j < len(b) {
+		ta, tb := a[i], b[j]
+		switch {
+		case ta.src.id < tb.src.id:
+			add(ta, ca)
+			i++
+		case ta.src.id > tb.src.id:
+			add(tb, cb)
+			j++
+		default:
+			if coef := ta.coef*ca + tb.coef*cb; coef != 0 {
+				r = append(r, term{src: ta.src, coef: coef})
+			}
+			i++
+			j++
+		}
+	}
+	for ; i < len(a); i++ {
+		add(a[i], ca)
+	}
+	for ; j < len(b); j++ {
+		add(b[j], cb)
+	}
+	return r
+}
@@ -0,0 +1,114 @@
+package approx
+
+import (
+	"testing"
+
+	"github.com/google/go-cmp/cmp"
+)
+
+func TestVar(t *testing.T) {
+	t.Parallel()
+	x := NewVar(New(10, 1))
+	y := NewVar(New(5, 0.5))
+	tests := []struct {
+		name     string
+		actual   Var
+		expected Float64
+	}{
+		{
+			name:     "x-x",
+			actual:   x.Sub(x),
+			expected: New(0, 0),
+		},
+		{
+			name:     "x+x",
+			actual:   x.Add(x),
+			expected: New(20, 2),
+		},
+		{
+			name:     "x/x",
+			actual:   x.Div(x),
+			expected: New(1, 0),
+		},
+		{
+			name:     "x*x",
+			actual:   x.Mul(x),
+			expected: New(100, 20),
+		},
+		{
+			name:     "x+y",
+			actual:   x.Add(y),
+			expected: New(15, 1.5),
+		},
+		{
+			name:     "(x+y)-y",
+			actual:   x.Add(y).Sub(y),
+			expected: New(10, 1),
+		},
+		{
+			name:     "x*y/y",
+			actual:   x.Mul(y).Div(y),
+			expected: New(10, 1),
+		},
+		{
+			name:     "2x-x",
+			actual:   x.Scale(2).Sub(x),
+			expected: New(10, 1),
+		},
+		{
+			name: "x^2-x",
+			actual: x.Apply(func(x float64) float64 {
+				return x * x
+			}, 1e-3).Sub(x),
+			expected: New(90, 19.000000000036284),
+		},
+		{
+			name: "x^2-2x",
+			actual: x.ApplyD(
+				func(x float64) float64 { return x * x },
+				func(x float64) float64 { return 2 * x },
+			).Sub(x.Scale(20)),
+			expected: New(-100, 0),
+		},
+		{
+			name:     "independent x",
+			actual:   x.Sub(NewVar(New(10, 1))),
+			expected: New(0, 2),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual := test.actual.Float64()
+			if !cmp.Equal(actual, test.expected, opts...) {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
+
+func TestCorrelation(t *testing.T) {
+	t.Parallel()
+	x := NewVar(New(1, 3))
+	y := NewVar(New(2, 4))
+	tests := []struct {
+		name     string
+		v, w     Var
+		expected float64
+	}{
+		{name: "self", v: x, w: x, expected: 1},
+		{name: "negated", v: x, w: x.Scale(-2), expected: -1},
+		{name: "independent", v: x, w: y, expected: 0},
+		{name: "partial", v: x, w: x.Add(y), expected: 0.6},
+		{name: "exact", v: x, w: NewVar(New(1, 0)), expected: 0},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			actual := Correlation(test.v, test.w)
+			if actual != test.expected {
+				t.Errorf("expected: %v, actual: %v", test.expected, actual)
+			}
+		})
+	}
+}
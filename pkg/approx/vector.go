@@ -0,0 +1,237 @@
+package approx
+
+import (
+	"fmt"
+	"math"
+)
+
+// Vec2 is a two dimensional vector whose components are approximate numbers,
+// e.g. a position measured with a separate error along each axis.
+type Vec2 struct {
+	X, Y Float64
+}
+
+// Vec3 is a three dimensional vector whose components are approximate
+// numbers.
+type Vec3 struct {
+	X, Y, Z Float64
+}
+
+// String implements Stringer.
+func (v Vec2) String() string {
+	return fmt.Sprintf("(%v, %v)", v.X, v.Y)
+}
+
+// Add computes v+w.
+func (v Vec2) Add(w Vec2) Vec2 {
+	return Vec2{X: Add(v.X, w.X), Y: Add(v.Y, w.Y)}
+}
+
+// Sub computes v-w.
+func (v Vec2) Sub(w Vec2) Vec2 {
+	return Vec2{X: Sub(v.X, w.X), Y: Sub(v.Y, w.Y)}
+}
+
+// Scale computes c*v for an exact number c.
+func (v Vec2) Scale(c float64) Vec2 {
+	return Vec2{X: v.X.Mul(c), Y: v.Y.Mul(c)}
+}
+
+// Length returns the Euclidean length of v, see Hypot.
+func (v Vec2) Length() Float64 {
+	return Hypot(v.X, v.Y)
+}
+
+// Distance returns the Euclidean distance between v and w.
+func (v Vec2) Distance(w Vec2) Float64 {
+	return v.Sub(w).Length()
+}
+
+// Norm returns the Euclidean norm of v, same as Length.
+func (v Vec2) Norm() Float64 {
+	return v.Length()
+}
+
+// NormSq returns the squared Euclidean norm of v, x^2+y^2.  Unlike
+// v.Dot(v), it accounts for each component appearing twice in the formula.
+func (v Vec2) NormSq() Float64 {
+	return sumSquares(v.X, v.Y)
+}
+
+// Dot returns the dot product of v and w.  The components of v and w are
+// treated as independent, use NormSq for the dot product of v with itself.
+func (v Vec2) Dot(w Vec2) Float64 {
+	return dot([]Float64{v.X, v.Y}, []Float64{w.X, w.Y})
+}
+
+// Cross returns the z component of the cross product of v and w, which are
+// taken to lie in the xy plane.
+func (v Vec2) Cross(w Vec2) Float64 {
+	return Sub(Mul(v.X, w.Y), Mul(v.Y, w.X))
+}
+
+// Rotate rotates v counterclockwise by the angle theta, in radians.
+//
+// Based on the first order Taylor expansion of the rotated components in the
+// components of v and in theta.  Since theta appears in both the sine and the
+// cosine, the contributions are added up in the worst case manner, see
+// ApplyNGrad.
+func (v Vec2) Rotate(theta Float64) Vec2 {
+	x, y := rotate(v.X, v.Y, theta)
+	return Vec2{X: x, Y: y}
+}
+
+// rotate rotates the vector (x, y) counterclockwise by theta.
+func rotate(x, y, theta Float64) (Float64, Float64) {
+	s, c := math.Sincos(theta.val)
+	rx := x.val*c - y.val*s
+	ry := x.val*s + y.val*c
+	fx := func(...float64) float64 { return rx }
+	fy := func(...float64) float64 { return ry }
+	// The partial derivatives with respect to x, y and theta.
+	gradX := func(...float64) []float64 { return []float64{c, -s, -ry} }
+	gradY := func(...float64) []float64 { return []float64{s, c, rx} }
+	return ApplyNGrad(fx, gradX, x, y, theta), ApplyNGrad(fy, gradY, x, y, theta)
+}
+
+// String implements Stringer.
+func (v Vec3) String() string {
+	return fmt.Sprintf("(%v, %v, %v)", v.X, v.Y, v.Z)
+}
+
+// Add computes v+w.
+func (v Vec3) Add(w Vec3) Vec3 {
+	return Vec3{X: Add(v.X, w.X), Y: Add(v.Y, w.Y), Z: Add(v.Z, w.Z)}
+}
+
+// Sub computes v-w.
+func (v Vec3) Sub(w Vec3) Vec3 {
+	return Vec3{X: Sub(v.X, w.X), Y: Sub(v.Y, w.Y), Z: Sub(v.Z, w.Z)}
+}
+
+// Scale computes c*v for an exact number c.
+func (v Vec3) Scale(c float64) Vec3 {
+	return Vec3{X: v.X.Mul(c), Y: v.Y.Mul(c), Z: v.Z.Mul(c)}
+}
+
+// Length returns the Euclidean length of v.
+func (v Vec3) Length() Float64 {
+	return norm(v.X, v.Y, v.Z)
+}
+
+// Distance returns the Euclidean distance between v and w.
+func (v Vec3) Distance(w Vec3) Float64 {
+	return v.Sub(w).Length()
+}
+
+// Norm returns the Euclidean norm of v, same as Length.
+func (v Vec3) Norm() Float64 {
+	return v.Length()
+}
+
+// NormSq returns the squared Euclidean norm of v, x^2+y^2+z^2.  Unlike
+// v.Dot(v), it accounts for each component appearing twice in the formula.
+func (v Vec3) NormSq() Float64 {
+	return sumSquares(v.X, v.Y, v.Z)
+}
+
+// Dot returns the dot product of v and w.  The components of v and w are
+// treated as independent, use NormSq for the dot product of v with itself.
+func (v Vec3) Dot(w Vec3) Float64 {
+	return dot([]Float64{v.X, v.Y, v.Z}, []Float64{w.X, w.Y, w.Z})
+}
+
+// Cross returns the cross product of v and w.  The components of v and w are
+// treated as independent.
+func (v Vec3) Cross(w Vec3) Vec3 {
+	return Vec3{
+		X: Sub(Mul(v.Y, w.Z), Mul(v.Z, w.Y)),
+		Y: Sub(Mul(v.Z, w.X), Mul(v.X, w.Z)),
+		Z: Sub(Mul(v.X, w.Y), Mul(v.Y, w.X)),
+	}
+}
+
+// RotateX rotates v counterclockwise by the angle theta, in radians, around
+// the x axis.  See Vec2.Rotate.
+func (v Vec3) RotateX(theta Float64) Vec3 {
+	v.Y, v.Z = rotate(v.Y, v.Z, theta)
+	return v
+}
+
+// RotateY rotates v counterclockwise by the angle theta, in radians, around
+// the y axis.  See Vec2.Rotate.
+func (v Vec3) RotateY(theta Float64) Vec3 {
+	v.Z, v.X = rotate(v.Z, v.X, theta)
+	return v
+}
+
+// RotateZ rotates v counterclockwise by the angle theta, in radians, around
+// the z axis.  See Vec2.Rotate.
+func (v Vec3) RotateZ(theta Float64) Vec3 {
+	v.X, v.Y = rotate(v.X, v.Y, theta)
+	return v
+}
+
+// norm computes the Euclidean length of the vector with components xs.
+//
+// Based on first order Taylor expansion around xs:
+//   d(norm) = sum(|x_i|*dx_i) / norm
+// which accounts for each component appearing twice in the formula.
+//
+// If all values are zero, the first order expansion is undefined, and the
+// delta is the largest length that the vector can have.
+func norm(xs ...Float64) Float64 {
+	var n, d float64
+	for _, x := range xs {
+		n = math.Hypot(n, x.val)
+	}
+	if n == 0 {
+		for _, x := range xs {
+			d = math.Hypot(d, x.delta)
+		}
+		return New(0, d)
+	}
+	r := New(n, 0)
+	for _, x := range xs {
+		r = New(n, r.delta+math.Abs(x.val)*x.delta/n).WithDistribution(combineDist(r, x))
+	}
+	return r
+}
+
+// sumSquares computes the sum of the squares of xs.
+//
+// Based on first order Taylor expansion around xs:
+//   d(sum) = sum(2*|x_i|*dx_i)
+func sumSquares(xs ...Float64) Float64 {
+	return ApplyNGrad(func(x ...float64) float64 {
+		var s float64
+		for _, xi := range x {
+			s += xi * xi
+		}
+		return s
+	}, func(x ...float64) []float64 {
+		g := make([]float64, len(x))
+		for i, xi := range x {
+			g[i] = 2 * xi
+		}
+		return g
+	}, xs...)
+}
+
+// dot computes the dot product of the vectors with components a and b.
+//
+// Based on first order Taylor expansion around a and b:
+//   d(dot) = sum(|b_i|*da_i + |a_i|*db_i)
+func dot(a, b []Float64) Float64 {
+	n := len(a)
+	return ApplyNGrad(func(x ...float64) float64 {
+		var s float64
+		for i := 0; i < n; i++ {
+			s += x[i] * x[n+i]
+		}
+		return s
+	}, func(x ...float64) []float64 {
+		// The derivative by a_i is b_i, and vice versa.
+		return append(append([]float64(nil), x[n:]...), x[:n]...)
+	}, append(append([]Float64(nil), a...), b...)...)
+}
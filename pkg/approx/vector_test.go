@@ -0,0 +1,144 @@
+package approx
+
+import (
+	"math"
+	"testing"
+)
+
+func TestVec2(t *testing.T) {
+	t.Parallel()
+	v := Vec2{X: New(3, 0.1), Y: New(4, 0.2)}
+	w := Vec2{X: New(1, 0.1), Y: New(1, 0.1)}
+	tests := []struct {
+		name     string
+		actual   Vec2
+		expected Vec2
+	}{
+		{name: "Add", actual: v.Add(w), expected: Vec2{X: New(4, 0.2), Y: New(5, 0.3)}},
+		{name: "Sub", actual: v.Sub(w), expected: Vec2{X: New(2, 0.2), Y: New(3, 0.3)}},
+		{name: "Scale", actual: v.Scale(-2), expected: Vec2{X: New(-6, 0.2), Y: New(-8, 0.4)}},
+		{
+			name:     "Rotate",
+			actual:   v.Rotate(New(math.Pi/2, 0.01)),
+			expected: Vec2{X: New(-4, 0.2+0.01*3), Y: New(3, 0.1+0.01*4)},
+		},
+		{name: "Rotate exact", actual: v.Rotate(New(0, 0)), expected: v},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if !near(test.actual.X, test.expected.X) || !near(test.actual.Y, test.expected.Y) {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
+
+func TestVec3(t *testing.T) {
+	t.Parallel()
+	v := Vec3{X: New(2, 0.1), Y: New(3, 0.1), Z: New(6, 0.1)}
+	tests := []struct {
+		name     string
+		actual   Vec3
+		expected Vec3
+	}{
+		{name: "Add", actual: v.Add(v), expected: Vec3{X: New(4, 0.2), Y: New(6, 0.2), Z: New(12, 0.2)}},
+		{name: "Sub", actual: v.Sub(Vec3{}), expected: v},
+		{name: "Scale", actual: v.Scale(0.5), expected: Vec3{X: New(1, 0.05), Y: New(1.5, 0.05), Z: New(3, 0.05)}},
+		{
+			name:     "RotateX",
+			actual:   v.RotateX(New(math.Pi/2, 0)),
+			expected: Vec3{X: New(2, 0.1), Y: New(-6, 0.1), Z: New(3, 0.1)},
+		},
+		{
+			name:     "RotateY",
+			actual:   v.RotateY(New(math.Pi/2, 0)),
+			expected: Vec3{X: New(6, 0.1), Y: New(3, 0.1), Z: New(-2, 0.1)},
+		},
+		{
+			name:     "RotateZ",
+			actual:   v.RotateZ(New(math.Pi/2, 0)),
+			expected: Vec3{X: New(-3, 0.1), Y: New(2, 0.1), Z: New(6, 0.1)},
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if !near(test.actual.X, test.expected.X) || !near(test.actual.Y, test.expected.Y) ||
+				!near(test.actual.Z, test.expected.Z) {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
+
+func TestLength(t *testing.T) {
+	t.Parallel()
+	tests := []struct {
+		name     string
+		actual   Float64
+		expected Float64
+	}{
+		{name: "Vec2", actual: Vec2{X: New(3, 0.1), Y: New(4, 0.2)}.Length(), expected: New(5, 0.22)},
+		{name: "Vec3", actual: Vec3{X: New(2, 0.1), Y: New(3, 0.1), Z: New(6, 0.1)}.Length(), expected: New(7, 11.0/70)},
+		{name: "Vec3 zero", actual: Vec3{X: New(0, 2), Y: New(0, 3), Z: New(0, 6)}.Length(), expected: New(0, 7)},
+		{
+			name:     "Distance",
+			actual:   Vec2{X: New(4, 0.1), Y: New(5, 0.1)}.Distance(Vec2{X: New(1, 0.1), Y: New(1, 0.1)}),
+			expected: New(5, 0.28),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if !near(test.actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
+
+func TestProducts(t *testing.T) {
+	t.Parallel()
+	v2 := Vec2{X: New(3, 0.1), Y: New(4, 0.2)}
+	w2 := Vec2{X: New(1, 0.1), Y: New(-2, 0.1)}
+	v3 := Vec3{X: New(2, 0.1), Y: New(3, 0.1), Z: New(6, 0.1)}
+	w3 := Vec3{X: New(1, 0), Y: New(0, 0), Z: New(-1, 0.2)}
+	tests := []struct {
+		name     string
+		actual   Float64
+		expected Float64
+	}{
+		{name: "Vec2.Dot", actual: v2.Dot(w2), expected: New(-5, 0.1+0.4+0.3+0.4)},
+		{name: "Vec2.NormSq", actual: v2.NormSq(), expected: New(25, 0.6+1.6)},
+		{name: "Vec2.Norm", actual: v2.Norm(), expected: v2.Length()},
+		{name: "Vec2.Cross", actual: v2.Cross(w2), expected: New(-10, 0.2+0.3+0.2+0.4)},
+		{name: "Vec3.Dot", actual: v3.Dot(w3), expected: New(-4, 0.1+0.1+1.2)},
+		{name: "Vec3.NormSq", actual: v3.NormSq(), expected: New(49, 0.4+0.6+1.2)},
+		{name: "Vec3.Norm", actual: v3.Norm(), expected: v3.Length()},
+		{
+			name:     "Vec3.NormSq uniform",
+			actual:   Vec3{X: New(1, 0.1).WithDistribution(Uniform)}.NormSq(),
+			expected: New(1, 0.2).WithDistribution(Uniform),
+		},
+	}
+	for _, test := range tests {
+		test := test
+		t.Run(test.name, func(t *testing.T) {
+			if !near(test.actual, test.expected) {
+				t.Errorf("expected: %v, actual: %v", test.expected, test.actual)
+			}
+		})
+	}
+}
+
+func TestCross(t *testing.T) {
+	t.Parallel()
+	v := Vec3{X: New(1, 0.1), Y: New(0, 0), Z: New(0, 0)}
+	w := Vec3{X: New(0, 0), Y: New(2, 0.1), Z: New(0, 0)}
+	expected := Vec3{X: New(0, 0), Y: New(0, 0), Z: New(2, 0.3)}
+	actual := v.Cross(w)
+	if !near(actual.X, expected.X) || !near(actual.Y, expected.Y) || !near(actual.Z, expected.Z) {
+		t.Errorf("expected: %v, actual: %v", expected, actual)
+	}
+}